@@ -0,0 +1,142 @@
+// Package anomaly mendeteksi pengeluaran yang tidak biasa secara statistik lokal
+// (median / MAD per kategori dan payee), tanpa layanan eksternal.
+package anomaly
+
+import (
+	"fmt"
+	"math"
+)
+
+// Jenis anomali
+const (
+	KindLargeTransaction = "large_transaction"
+	KindCategorySpike    = "category_spike"
+	KindPriceIncrease    = "price_increase"
+)
+
+// Ambang deteksi
+const (
+	// Modified z-score di atas ini dianggap outlier (Iglewicz & Hoaglin)
+	ZThreshold = 3.5
+
+	// Minimal jumlah data histori sebelum detektor dipakai
+	MinSamples      = 5
+	MinMonths       = 3
+	MinPayeeHistory = 2
+
+	// Transaksi besar minimal LargeRatio x median, supaya histori yang nyaris seragam
+	// tidak menandai selisih kecil
+	LargeRatio = 2.0
+
+	// Pengeluaran bulan berjalan >= SpikeRatio x median bulanan
+	SpikeRatio = 3.0
+
+	// Harga payee dianggap stabil jika MAD / median <= StablePriceTolerance,
+	// dan naik jika lebih dari PriceIncreaseRatio di atas median
+	StablePriceTolerance = 0.05
+	PriceIncreaseRatio   = 0.05
+)
+
+// Finding adalah hasil satu detektor
+type Finding struct {
+	Kind        string
+	Category    string
+	Payee       string
+	Amount      float64
+	Expected    float64
+	Score       float64
+	Explanation string
+}
+
+// LargeTransaction memeriksa amount terhadap histori amount di kategori (atau payee) yang sama.
+// scope dipakai di penjelasan, misal "kategori makanan".
+func LargeTransaction(amount float64, history []float64, scope string) (Finding, bool) {
+	if len(history) < MinSamples {
+		return Finding{}, false
+	}
+	med := Median(history)
+	z := RobustZ(amount, history)
+	if z < ZThreshold || amount < LargeRatio*med {
+		return Finding{}, false
+	}
+	return Finding{
+		Kind:     KindLargeTransaction,
+		Amount:   amount,
+		Expected: med,
+		Score:    finite(z),
+		Explanation: fmt.Sprintf("Pengeluaran %s jauh di atas biasanya untuk %s (median %s dari %d transaksi, skor %.1f)",
+			Rupiah(amount), scope, Rupiah(med), len(history), finite(z)),
+	}, true
+}
+
+// CategorySpike membandingkan pengeluaran kategori bulan berjalan dengan total bulanan
+// bulan-bulan sebelumnya.
+func CategorySpike(category string, monthToDate float64, monthly []float64) (Finding, bool) {
+	if len(monthly) < MinMonths {
+		return Finding{}, false
+	}
+	med := Median(monthly)
+	if med <= 0 || monthToDate < SpikeRatio*med {
+		return Finding{}, false
+	}
+	ratio := monthToDate / med
+	return Finding{
+		Kind:     KindCategorySpike,
+		Category: category,
+		Amount:   monthToDate,
+		Expected: med,
+		Score:    ratio,
+		Explanation: fmt.Sprintf("Pengeluaran %s bulan ini sudah %s, %.1fx rata-rata bulanan (median %s dari %d bulan)",
+			category, Rupiah(monthToDate), ratio, Rupiah(med), len(monthly)),
+	}, true
+}
+
+// PriceIncrease mendeteksi payee dengan harga stabil (langganan, tagihan) yang tiba-tiba naik
+func PriceIncrease(payee string, amount float64, history []float64) (Finding, bool) {
+	if len(history) < MinPayeeHistory {
+		return Finding{}, false
+	}
+	med := Median(history)
+	if med <= 0 || MAD(history)/med > StablePriceTolerance {
+		return Finding{}, false
+	}
+	change := (amount - med) / med
+	if change <= PriceIncreaseRatio {
+		return Finding{}, false
+	}
+	return Finding{
+		Kind:     KindPriceIncrease,
+		Payee:    payee,
+		Amount:   amount,
+		Expected: med,
+		Score:    change * 100,
+		Explanation: fmt.Sprintf("Tagihan %s naik %.0f%% dari biasanya %s menjadi %s",
+			payee, change*100, Rupiah(med), Rupiah(amount)),
+	}, true
+}
+
+// MaxScore menggantikan skor tak hingga (histori tanpa variasi sama sekali)
+const MaxScore = 99.0
+
+func finite(z float64) float64 {
+	if math.IsInf(z, 0) || math.IsNaN(z) || z > MaxScore {
+		return MaxScore
+	}
+	return z
+}
+
+// Rupiah memformat angka jadi "Rp1.234.567"
+func Rupiah(v float64) string {
+	s := fmt.Sprintf("%.0f", math.Abs(v))
+	var out []byte
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, s[i])
+	}
+	if v < 0 {
+		return "-Rp" + string(out)
+	}
+	return "Rp" + string(out)
+}
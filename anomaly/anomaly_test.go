@@ -0,0 +1,159 @@
+package anomaly
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLargeTransaction(t *testing.T) {
+	history := []float64{100, 110, 90, 105, 95} // median 100, MAD 5
+
+	tests := []struct {
+		name    string
+		amount  float64
+		history []float64
+		ok      bool
+		score   float64
+	}{
+		{"too little history", 1000, history[:MinSamples-1], false, 0},
+		{"normal amount", 110, history, false, 0},
+		{"outlier below LargeRatio x median", 150, history, false, 0},
+		{"large", 250, history, true, madScale * 150 / 5},
+		{"huge score is capped", 1000, history, true, MaxScore},
+		{"uniform history gives infinite score", 300, []float64{100, 100, 100, 100, 100}, true, MaxScore},
+		{"uniform history, same amount", 100, []float64{100, 100, 100, 100, 100}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := LargeTransaction(tt.amount, tt.history, "kategori makanan")
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if f.Kind != KindLargeTransaction || f.Amount != tt.amount || f.Expected != Median(tt.history) {
+				t.Errorf("finding = %+v", f)
+			}
+			if math.Abs(f.Score-tt.score) > 1e-9 {
+				t.Errorf("Score = %v, want %v", f.Score, tt.score)
+			}
+			if math.IsInf(f.Score, 0) || strings.Contains(f.Explanation, "Inf") {
+				t.Errorf("skor tak hingga bocor: %+v", f)
+			}
+			if !strings.Contains(f.Explanation, "kategori makanan") {
+				t.Errorf("Explanation = %q", f.Explanation)
+			}
+		})
+	}
+}
+
+func TestCategorySpike(t *testing.T) {
+	tests := []struct {
+		name    string
+		mtd     float64
+		monthly []float64
+		ok      bool
+		score   float64
+	}{
+		{"too few months", 9000, []float64{1000, 1200}, false, 0},
+		{"no spending before", 9000, []float64{0, 0, 0}, false, 0},
+		{"below ratio", 3500, []float64{1000, 1200, 1400}, false, 0},
+		{"exactly ratio", 3600, []float64{1000, 1200, 1400}, true, 3},
+		{"spike", 6000, []float64{1000, 1200, 1400, 1100}, true, 6000 / 1150.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := CategorySpike("makanan", tt.mtd, tt.monthly)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if f.Kind != KindCategorySpike || f.Category != "makanan" || f.Amount != tt.mtd {
+				t.Errorf("finding = %+v", f)
+			}
+			if math.Abs(f.Score-tt.score) > 1e-9 {
+				t.Errorf("Score = %v, want %v", f.Score, tt.score)
+			}
+		})
+	}
+}
+
+func TestPriceIncrease(t *testing.T) {
+	stable := []float64{54000, 54000, 54000}
+
+	tests := []struct {
+		name    string
+		amount  float64
+		history []float64
+		ok      bool
+		score   float64
+	}{
+		{"too little history", 65000, stable[:1], false, 0},
+		{"unchanged", 54000, stable, false, 0},
+		{"within tolerance", 56000, stable, false, 0},
+		{"cheaper", 40000, stable, false, 0},
+		{"increase", 64800, stable, true, 20},
+		{"small jitter is still stable", 64800, []float64{54000, 54500, 53800, 54000}, true, 20},
+		{"unstable price", 90000, []float64{50000, 80000, 30000}, false, 0},
+		{"free so far", 10000, []float64{0, 0, 0}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := PriceIncrease("Netflix", tt.amount, tt.history)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if f.Kind != KindPriceIncrease || f.Payee != "Netflix" || f.Expected != Median(tt.history) {
+				t.Errorf("finding = %+v", f)
+			}
+			if math.Abs(f.Score-tt.score) > 1e-9 {
+				t.Errorf("Score = %v, want %v", f.Score, tt.score)
+			}
+		})
+	}
+}
+
+func TestFinite(t *testing.T) {
+	tests := []struct {
+		z, want float64
+	}{
+		{3.7, 3.7},
+		{-2, -2},
+		{MaxScore, MaxScore},
+		{150, MaxScore},
+		{math.Inf(1), MaxScore},
+		{math.Inf(-1), MaxScore},
+		{math.NaN(), MaxScore},
+	}
+	for _, tt := range tests {
+		if got := finite(tt.z); got != tt.want {
+			t.Errorf("finite(%v) = %v, want %v", tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestRupiah(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want string
+	}{
+		{0, "Rp0"},
+		{999, "Rp999"},
+		{1000, "Rp1.000"},
+		{1234567, "Rp1.234.567"},
+		{1234.6, "Rp1.235"},
+		{-1500, "-Rp1.500"},
+	}
+	for _, tt := range tests {
+		if got := Rupiah(tt.v); got != tt.want {
+			t.Errorf("Rupiah(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package anomaly
+
+import (
+	"math"
+	"sort"
+)
+
+// madScale membuat MAD sebanding dengan simpangan baku untuk data normal
+const madScale = 0.6745
+
+// Median dari values (tidak mengubah slice asli)
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// MAD adalah median absolute deviation
+func MAD(values []float64) float64 {
+	med := Median(values)
+	dev := make([]float64, len(values))
+	for i, v := range values {
+		dev[i] = math.Abs(v - med)
+	}
+	return Median(dev)
+}
+
+// RobustZ adalah modified z-score x terhadap values (median / MAD).
+// Jika MAD 0 (hampir semua nilai sama) dipakai z-score biasa dengan mean / simpangan baku,
+// dan jika itu pun 0 hasilnya 0 untuk x yang sama dan +Inf / -Inf untuk selainnya.
+func RobustZ(x float64, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	med := Median(values)
+	if mad := MAD(values); mad > 0 {
+		return madScale * (x - med) / mad
+	}
+
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	if sd := math.Sqrt(variance / float64(len(values))); sd > 0 {
+		return (x - mean) / sd
+	}
+
+	switch {
+	case x > med:
+		return math.Inf(1)
+	case x < med:
+		return math.Inf(-1)
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{7}, 7},
+		{"odd", []float64{3, 1, 2}, 2},
+		{"even", []float64{4, 1, 3, 2}, 2.5},
+		{"negative", []float64{-5, -1, -3}, -3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Median(tt.values); got != tt.want {
+				t.Errorf("Median(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+
+	values := []float64{3, 1, 2}
+	Median(values)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("Median mengubah slice asli: %v", values)
+	}
+}
+
+func TestMAD(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"all equal", []float64{5, 5, 5}, 0},
+		{"mostly equal", []float64{10, 10, 10, 10, 20}, 0},
+		{"outlier barely moves it", []float64{1, 2, 3, 4, 100}, 1},
+		{"even", []float64{1, 2, 4, 8}, 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MAD(tt.values); got != tt.want {
+				t.Errorf("MAD(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobustZ(t *testing.T) {
+	tests := []struct {
+		name   string
+		x      float64
+		values []float64
+		want   float64
+	}{
+		{"empty history", 100, nil, 0},
+		{"median and MAD", 100, []float64{1, 2, 3, 4, 100}, madScale * 97},
+		{"below median", 1, []float64{1, 2, 3, 4, 100}, -madScale * 2},
+		{"MAD zero falls back to standard score", 20, []float64{10, 10, 10, 10, 20}, 2},
+		{"no variation at the median", 5, []float64{5, 5, 5}, 0},
+		{"no variation above", 6, []float64{5, 5, 5}, math.Inf(1)},
+		{"no variation below", 4, []float64{5, 5, 5}, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RobustZ(tt.x, tt.values)
+			if got != tt.want && math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("RobustZ(%v, %v) = %v, want %v", tt.x, tt.values, got, tt.want)
+			}
+		})
+	}
+}
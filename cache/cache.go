@@ -0,0 +1,127 @@
+// Package cache menyimpan response dashboard yang sudah jadi. Invalidasi memakai
+// counter versi per bulan: setiap key menyertakan versi bulan-bulan yang dicakup
+// request, jadi perubahan transaksi di suatu bulan otomatis membuat key lama
+// tidak terpakai lagi tanpa perlu menghapusnya satu per satu.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Store adalah backend cache
+type Store interface {
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	// Incr menaikkan counter versi (counter tidak pernah kedaluwarsa)
+	Incr(keys ...string) error
+	// Counters membaca counter versi, key yang belum ada bernilai 0
+	Counters(keys ...string) ([]int64, error)
+}
+
+// Default adalah backend aktif, nil jika cache dimatikan
+var Default Store
+
+// TTL adalah umur maksimal entry, diatur lewat CACHE_TTL (detik, default 300)
+var TTL = 5 * time.Minute
+
+// Key counter versi
+const (
+	prefix   = "cf:"
+	epochKey = prefix + "v:epoch"
+	allKey   = prefix + "v:all"
+)
+
+// Init memilih backend dari CACHE_DRIVER: memory (default), redis, atau off.
+// Nilai lain hanya di-log dan cache dimatikan, supaya salah konfigurasi tidak membuat server gagal start.
+func Init() {
+	if secs, err := strconv.Atoi(os.Getenv("CACHE_TTL")); err == nil && secs > 0 {
+		TTL = time.Duration(secs) * time.Second
+	}
+
+	switch os.Getenv("CACHE_DRIVER") {
+	case "", "memory":
+		Default = NewMemory(10000)
+	case "redis":
+		dbIndex, _ := strconv.Atoi(os.Getenv("REDIS_DB"))
+		Default = NewRedis(envOr("REDIS_ADDR", "localhost:6379"), os.Getenv("REDIS_PASSWORD"), dbIndex)
+	case "off":
+		Default = nil
+	default:
+		log.Printf("CACHE_DRIVER %q tidak dikenal, cache dimatikan", os.Getenv("CACHE_DRIVER"))
+		Default = nil
+	}
+}
+
+// MonthKey adalah key counter versi satu bulan, month format YYYY-MM
+func MonthKey(month string) string {
+	return prefix + "v:month:" + month
+}
+
+// Versions membaca versi yang menjadi dependensi sebuah entry: epoch global ditambah
+// versi tiap bulan di months. months nil berarti entry bergantung pada semua bulan.
+// Hasilnya dipakai sebagai bagian dari key, sehingga entry otomatis basi saat versi naik.
+func Versions(months []string) (string, error) {
+	if Default == nil {
+		return "", nil
+	}
+	keys := []string{epochKey}
+	if months == nil {
+		keys = append(keys, allKey)
+	}
+	for _, m := range months {
+		keys = append(keys, MonthKey(m))
+	}
+
+	counters, err := Default.Counters(keys...)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, len(counters))
+	for i, c := range counters {
+		parts[i] = strconv.FormatInt(c, 10)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// InvalidateMonths dipanggil setelah transaksi di bulan-bulan tersebut berubah
+func InvalidateMonths(months ...string) {
+	if Default == nil || len(months) == 0 {
+		return
+	}
+	keys := []string{allKey}
+	for _, m := range months {
+		keys = append(keys, MonthKey(m))
+	}
+	if err := Default.Incr(keys...); err != nil {
+		log.Printf("invalidasi cache gagal: %v", err)
+	}
+}
+
+// InvalidateAll membuat semua entry basi, misal setelah import atau rebuild rollup
+func InvalidateAll() {
+	if Default == nil {
+		return
+	}
+	if err := Default.Incr(epochKey); err != nil {
+		log.Printf("invalidasi cache gagal: %v", err)
+	}
+}
+
+// Key membuat key entry dari bagian-bagiannya
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return prefix + "r:" + hex.EncodeToString(sum[:])
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,27 @@
+package cache
+
+import "testing"
+
+func TestInitDriver(t *testing.T) {
+	tests := []struct {
+		driver string
+		check  func(Store) bool
+	}{
+		{"", func(s Store) bool { _, ok := s.(*Memory); return ok }},
+		{"memory", func(s Store) bool { _, ok := s.(*Memory); return ok }},
+		{"redis", func(s Store) bool { _, ok := s.(*Redis); return ok }},
+		{"off", func(s Store) bool { return s == nil }},
+		{"memcached", func(s Store) bool { return s == nil }},
+	}
+	defer func(d Store) { Default = d }(Default)
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			t.Setenv("CACHE_DRIVER", tt.driver)
+			Default = NewMemory(1)
+			Init()
+			if !tt.check(Default) {
+				t.Errorf("CACHE_DRIVER=%q: Default = %T", tt.driver, Default)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// Memory adalah cache in-process. Counter versi hanya berlaku di proses ini,
+// jadi untuk beberapa replica pakai Redis.
+type Memory struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]memoryEntry
+	counters   map[string]int64
+}
+
+func NewMemory(maxEntries int) *Memory {
+	return &Memory{
+		maxEntries: maxEntries,
+		entries:    map[string]memoryEntry{},
+		// Epoch dimulai dari waktu start supaya ETag dari proses sebelumnya tidak dianggap masih valid
+		counters: map[string]int64{epochKey: time.Now().UnixNano()},
+	}
+}
+
+func (m *Memory) Get(key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+func (m *Memory) Set(key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if len(m.entries) >= m.maxEntries {
+		// Buang yang kedaluwarsa dulu, jika masih penuh buang sembarang entry
+		for k, e := range m.entries {
+			if now.After(e.expires) {
+				delete(m.entries, k)
+			}
+		}
+		for k := range m.entries {
+			if len(m.entries) < m.maxEntries {
+				break
+			}
+			delete(m.entries, k)
+		}
+	}
+	m.entries[key] = memoryEntry{value: value, expires: now.Add(ttl)}
+	return nil
+}
+
+func (m *Memory) Incr(keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range keys {
+		m.counters[k]++
+	}
+	return nil
+}
+
+func (m *Memory) Counters(keys ...string) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]int64, len(keys))
+	for i, k := range keys {
+		out[i] = m.counters[k]
+	}
+	return out, nil
+}
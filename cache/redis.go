@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	redisTimeout  = 2 * time.Second
+	redisMaxIdle  = 8
+	redisMaxReply = 64 << 20
+)
+
+// Redis adalah client minimal protokol RESP untuk server yang kompatibel Redis
+// (Redis, Valkey, KeyDB, Dragonfly). Hanya perintah yang dibutuhkan cache.
+type Redis struct {
+	addr     string
+	password string
+	db       int
+	idle     chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func NewRedis(addr, password string, db int) *Redis {
+	return &Redis{addr: addr, password: password, db: db, idle: make(chan *redisConn, redisMaxIdle)}
+}
+
+// redisError adalah balasan error (-ERR ...) dari server; koneksinya tetap sehat
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func (c *Redis) Get(key string) ([]byte, bool, error) {
+	replies, err := c.do([]string{"GET", key})
+	if err != nil {
+		return nil, false, err
+	}
+	if replies[0] == nil {
+		return nil, false, nil
+	}
+	b, ok := replies[0].([]byte)
+	if !ok {
+		return nil, false, errors.New("redis: balasan GET tidak terduga")
+	}
+	return b, true, nil
+}
+
+func (c *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	_, err := c.do([]string{"SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10)})
+	return err
+}
+
+func (c *Redis) Incr(keys ...string) error {
+	cmds := make([][]string, len(keys))
+	for i, k := range keys {
+		cmds[i] = []string{"INCR", k}
+	}
+	_, err := c.do(cmds...)
+	return err
+}
+
+func (c *Redis) Counters(keys ...string) ([]int64, error) {
+	replies, err := c.do(append([]string{"MGET"}, keys...))
+	if err != nil {
+		return nil, err
+	}
+	values, ok := replies[0].([]interface{})
+	if !ok || len(values) != len(keys) {
+		return nil, errors.New("redis: balasan MGET tidak terduga")
+	}
+
+	out := make([]int64, len(keys))
+	for i, v := range values {
+		if b, ok := v.([]byte); ok {
+			out[i], _ = strconv.ParseInt(string(b), 10, 64)
+		}
+	}
+	return out, nil
+}
+
+// do mengirim satu atau beberapa perintah sekaligus (pipeline) dan membaca semua balasannya
+func (c *Redis) do(cmds ...[]string) ([]interface{}, error) {
+	conn, err := c.conn()
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	replies, err := conn.roundTrip(cmds...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		conn.Close()
+		return nil, err
+	}
+	c.release(conn)
+	return replies, err
+}
+
+func (c *Redis) conn() (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+	}
+
+	nc, err := net.DialTimeout("tcp", c.addr, redisTimeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+	conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	var setup [][]string
+	if c.password != "" {
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	if len(setup) > 0 {
+		if _, err := conn.roundTrip(setup...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (c *Redis) release(conn *redisConn) {
+	select {
+	case c.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (conn *redisConn) roundTrip(cmds ...[]string) ([]interface{}, error) {
+	w := bufio.NewWriter(conn)
+	for _, cmd := range cmds {
+		fmt.Fprintf(w, "*%d\r\n", len(cmd))
+		for _, arg := range cmd {
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	// Semua balasan tetap dibaca walaupun ada yang error supaya koneksi tetap sinkron
+	replies := make([]interface{}, len(cmds))
+	var firstErr error
+	for i := range cmds {
+		reply, err := conn.readReply()
+		var replyErr redisError
+		if err != nil && !errors.As(err, &replyErr) {
+			return nil, err
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		replies[i] = reply
+	}
+	return replies, firstErr
+}
+
+func (conn *redisConn) readReply() (interface{}, error) {
+	line, err := conn.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("redis: balasan tidak valid")
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil || n > redisMaxReply {
+			return nil, errors.New("redis: panjang bulk tidak valid")
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(conn.r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, errors.New("redis: panjang array tidak valid")
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = conn.readReply(); err != nil {
+				var replyErr redisError
+				if !errors.As(err, &replyErr) {
+					return nil, err
+				}
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: tipe balasan %q tidak dikenal", kind)
+}
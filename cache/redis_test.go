@@ -0,0 +1,320 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis adalah server RESP minimal untuk test: GET, SET, INCR, MGET, AUTH, SELECT.
+// Key yang diawali "readonly:" dibalas error seperti replica read-only, dan key
+// "drop" membuat server menutup koneksi.
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu    sync.Mutex
+	data  map[string]string
+	cmds  [][]string
+	conns int
+}
+
+func startFakeRedis(t *testing.T, password string) *fakeRedis {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeRedis{ln: ln, password: password, data: map[string]string{}}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns++
+			s.mu.Unlock()
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRedis) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	authed := s.password == ""
+	for {
+		cmd, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.cmds = append(s.cmds, cmd)
+		reply := s.reply(cmd, &authed)
+		s.mu.Unlock()
+		if reply == "" {
+			return
+		}
+		io.WriteString(c, reply)
+	}
+}
+
+func (s *fakeRedis) reply(cmd []string, authed *bool) string {
+	name := strings.ToUpper(cmd[0])
+	if name == "AUTH" {
+		if cmd[1] != s.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		*authed = true
+		return "+OK\r\n"
+	}
+	if !*authed {
+		return "-NOAUTH Authentication required.\r\n"
+	}
+	if len(cmd) > 1 && cmd[1] == "drop" {
+		return ""
+	}
+	if len(cmd) > 1 && strings.HasPrefix(cmd[1], "readonly:") {
+		return "-READONLY You can't write against a read only replica.\r\n"
+	}
+
+	switch name {
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		v, ok := s.data[cmd[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case "SET":
+		s.data[cmd[1]] = cmd[2]
+		return "+OK\r\n"
+	case "INCR":
+		n, _ := strconv.ParseInt(s.data[cmd[1]], 10, 64)
+		n++
+		s.data[cmd[1]] = strconv.FormatInt(n, 10)
+		return fmt.Sprintf(":%d\r\n", n)
+	case "MGET":
+		out := fmt.Sprintf("*%d\r\n", len(cmd)-1)
+		for _, k := range cmd[1:] {
+			if v, ok := s.data[k]; ok {
+				out += fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			} else {
+				out += "$-1\r\n"
+			}
+		}
+		return out
+	}
+	return "-ERR unknown command '" + cmd[0] + "'\r\n"
+}
+
+func (s *fakeRedis) commands() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]string(nil), s.cmds...)
+}
+
+func (s *fakeRedis) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	cmd := make([]string, n)
+	for i := range cmd {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		cmd[i] = string(buf[:size])
+	}
+	return cmd, nil
+}
+
+func TestRedisGetSet(t *testing.T) {
+	srv := startFakeRedis(t, "")
+	c := NewRedis(srv.ln.Addr().String(), "", 0)
+
+	if _, ok, err := c.Get("missing"); err != nil || ok {
+		t.Fatalf("Get(missing) = ok %v, err %v", ok, err)
+	}
+
+	value := []byte("{\"saldo\":1}\r\n$3\r\nbukan perintah")
+	if err := c.Set("k", value, 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := c.Get("k")
+	if err != nil || !ok || string(got) != string(value) {
+		t.Fatalf("Get(k) = %q, %v, %v", got, ok, err)
+	}
+
+	want := [][]string{{"GET", "missing"}, {"SET", "k", string(value), "PX", "1500"}, {"GET", "k"}}
+	if cmds := srv.commands(); !reflect.DeepEqual(cmds, want) {
+		t.Errorf("commands = %q, want %q", cmds, want)
+	}
+	if n := srv.connections(); n != 1 {
+		t.Errorf("connections = %d, want 1 (koneksi dipakai ulang)", n)
+	}
+}
+
+func TestRedisCounters(t *testing.T) {
+	srv := startFakeRedis(t, "")
+	c := NewRedis(srv.ln.Addr().String(), "", 0)
+
+	if err := c.Incr("a", "b", "a"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Counters("a", "b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{2, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Counters = %v, want %v", got, want)
+	}
+}
+
+func TestRedisAuthAndSelect(t *testing.T) {
+	srv := startFakeRedis(t, "rahasia")
+
+	c := NewRedis(srv.ln.Addr().String(), "rahasia", 2)
+	if err := c.Set("k", []byte("v"), time.Second); err != nil {
+		t.Fatal(err)
+	}
+	cmds := srv.commands()
+	if len(cmds) != 3 || cmds[0][0] != "AUTH" || cmds[1][0] != "SELECT" || cmds[1][1] != "2" || cmds[2][0] != "SET" {
+		t.Errorf("commands = %q, want AUTH, SELECT 2, SET", cmds)
+	}
+
+	wrong := NewRedis(srv.ln.Addr().String(), "salah", 0)
+	_, _, err := wrong.Get("k")
+	var replyErr redisError
+	if !errors.As(err, &replyErr) || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("err = %v, want WRONGPASS", err)
+	}
+	if len(wrong.idle) != 0 {
+		t.Error("koneksi yang gagal AUTH tidak boleh masuk pool")
+	}
+}
+
+func TestRedisErrorReplyKeepsConnection(t *testing.T) {
+	srv := startFakeRedis(t, "")
+	c := NewRedis(srv.ln.Addr().String(), "", 0)
+
+	err := c.Set("readonly:k", []byte("v"), time.Second)
+	var replyErr redisError
+	if !errors.As(err, &replyErr) || !strings.Contains(err.Error(), "READONLY") {
+		t.Fatalf("err = %v, want READONLY", err)
+	}
+
+	// Error di tengah pipeline: balasan lain tetap dibaca supaya koneksi sinkron
+	if err := c.Incr("a", "readonly:b", "a"); !errors.As(err, &replyErr) {
+		t.Fatalf("err = %v, want redisError", err)
+	}
+	got, err := c.Counters("a")
+	if err != nil || got[0] != 2 {
+		t.Fatalf("Counters(a) = %v, %v, want [2]", got, err)
+	}
+	if n := srv.connections(); n != 1 {
+		t.Errorf("connections = %d, want 1", n)
+	}
+}
+
+func TestRedisBrokenConnectionIsDropped(t *testing.T) {
+	srv := startFakeRedis(t, "")
+	c := NewRedis(srv.ln.Addr().String(), "", 0)
+
+	if _, _, err := c.Get("drop"); err == nil {
+		t.Fatal("Get(drop) tanpa error")
+	}
+	if err := c.Set("k", []byte("v"), time.Second); err != nil {
+		t.Fatalf("Set setelah koneksi putus: %v", err)
+	}
+	if n := srv.connections(); n != 2 {
+		t.Errorf("connections = %d, want 2 (koneksi rusak tidak dipakai ulang)", n)
+	}
+}
+
+func TestRedisDialError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	if _, _, err := NewRedis(addr, "", 0).Get("k"); err == nil {
+		t.Error("Get ke server mati tanpa error")
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    interface{}
+		wantErr string
+	}{
+		{"simple string", "+OK\r\n", "OK", ""},
+		{"integer", ":42\r\n", int64(42), ""},
+		{"bulk", "$5\r\na\r\nbc\r\n", []byte("a\r\nbc"), ""},
+		{"empty bulk", "$0\r\n\r\n", []byte{}, ""},
+		{"nil bulk", "$-1\r\n", nil, ""},
+		{"array", "*3\r\n$1\r\na\r\n$-1\r\n:7\r\n", []interface{}{[]byte("a"), nil, int64(7)}, ""},
+		{"nested array", "*1\r\n*1\r\n+x\r\n", []interface{}{[]interface{}{"x"}}, ""},
+		{"nil array", "*-1\r\n", nil, ""},
+		{"error inside array", "*2\r\n-ERR no\r\n:1\r\n", []interface{}{nil, int64(1)}, ""},
+		{"error", "-ERR wrong type\r\n", nil, "redis: ERR wrong type"},
+		{"unknown type", "?x\r\n", nil, "tidak dikenal"},
+		{"missing CR", "+OK\n", nil, "tidak valid"},
+		{"bad bulk length", "$x\r\n", nil, "panjang bulk"},
+		{"bulk too large", "$999999999\r\n", nil, "panjang bulk"},
+		{"short bulk", "$10\r\nabc\r\n", nil, "EOF"},
+		{"bad array length", "*x\r\n", nil, "panjang array"},
+		{"truncated array", "*2\r\n:1\r\n", nil, "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &redisConn{r: bufio.NewReader(strings.NewReader(tt.raw))}
+			got, err := conn.readReply()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readReply() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Command rebuild-summaries mengisi ulang tabel rollup dashboard (daily_summaries dan
+// daily_category_summaries) dari tabel transactions. Jalankan setelah import data langsung
+// ke database atau setelah APP_TIMEZONE diganti.
+//
+//	go run ./cmd/rebuild-summaries
+package main
+
+import (
+	"log"
+	"time"
+	_ "time/tzdata" // APP_TIMEZONE tetap bisa dimuat di image tanpa tzdata
+
+	"cash-flow-go/cache"
+	db "cash-flow-go/database"
+	"cash-flow-go/handlers"
+)
+
+func main() {
+	db.Init()
+
+	start := time.Now()
+	if err := db.RebuildDailySummaries(db.DB, handlers.Timezone()); err != nil {
+		log.Fatal("Gagal rebuild rollup: " + err.Error())
+	}
+
+	var days int64
+	db.DB.Table("daily_summaries").Distinct("day").Count(&days)
+	// Cache Redis dipakai bersama API, jadi semua response dashboard lama dibuang
+	cache.Init()
+	cache.InvalidateAll()
+
+	log.Printf("Rollup selesai: %d hari dalam %s", days, time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAnomalyNotFound dikembalikan jika anomali dengan ID tersebut tidak ada
+var ErrAnomalyNotFound = errors.New("anomali tidak ditemukan")
+
+// SaveAnomalies menyimpan anomali baru; yang DedupKey-nya sudah ada dilewati.
+// Mengembalikan anomali yang benar-benar baru tersimpan.
+func SaveAnomalies(list []models.Anomaly) ([]models.Anomaly, error) {
+	var saved []models.Anomaly
+	for _, a := range list {
+		res := DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dedup_key"}}, DoNothing: true}).Create(&a)
+		if res.Error != nil {
+			return saved, res.Error
+		}
+		if res.RowsAffected > 0 {
+			saved = append(saved, a)
+		}
+	}
+	return saved, nil
+}
+
+// ListAnomalies mengambil anomali terbaru, opsional difilter kind
+func ListAnomalies(kind string, includeDismissed bool, limit int) ([]models.Anomaly, error) {
+	q := DB.Order("created_at DESC").Limit(limit)
+	if kind != "" {
+		q = q.Where("kind = ?", kind)
+	}
+	if !includeDismissed {
+		q = q.Where("dismissed = ?", false)
+	}
+
+	var list []models.Anomaly
+	err := q.Find(&list).Error
+	return list, err
+}
+
+// DismissAnomaly menandai anomali sudah dilihat / bukan masalah
+func DismissAnomaly(id uint) error {
+	res := DB.Model(&models.Anomaly{}).Where("id = ?", id).Update("dismissed", true)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrAnomalyNotFound
+	}
+	return nil
+}
+
+// anomalyScanID adalah ID satu-satunya baris anomaly_scans
+const anomalyScanID = 1
+
+// LastAnomalyScan mengembalikan waktu scan anomali terakhir; zero jika belum pernah scan
+func LastAnomalyScan() (time.Time, error) {
+	var scan models.AnomalyScan
+	err := DB.First(&scan, anomalyScanID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, nil
+	}
+	return scan.LastScanAt, err
+}
+
+// SaveAnomalyScan mencatat waktu scan anomali terakhir
+func SaveAnomalyScan(at time.Time) error {
+	scan := models.AnomalyScan{ID: anomalyScanID, LastScanAt: at}
+	return DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_scan_at"}),
+	}).Create(&scan).Error
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"errors"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrBillNotFound dikembalikan jika tagihan dengan ID tersebut tidak ada
+	ErrBillNotFound = errors.New("tagihan tidak ditemukan")
+	// ErrBillPaymentExists dikembalikan jika periode sudah dibayar atau transaksinya sudah dipakai tagihan lain
+	ErrBillPaymentExists = errors.New("tagihan periode ini sudah dibayar atau transaksinya sudah dipakai")
+	// ErrBillPaymentNotFound dikembalikan saat membatalkan pembayaran periode yang belum dibayar
+	ErrBillPaymentNotFound = errors.New("tagihan periode ini belum dibayar")
+)
+
+// ListBills mengambil tagihan, urut tanggal jatuh tempo
+func ListBills(activeOnly bool) ([]models.Bill, error) {
+	q := DB.Order("due_day ASC, id ASC")
+	if activeOnly {
+		q = q.Where("is_active = ?", true)
+	}
+	var bills []models.Bill
+	err := q.Find(&bills).Error
+	return bills, err
+}
+
+// ActiveBillsByPayee mengambil tagihan aktif dengan payee tersebut (tidak case sensitive)
+func ActiveBillsByPayee(payee string) ([]models.Bill, error) {
+	var bills []models.Bill
+	err := DB.Where("is_active = ? AND LOWER(payee) = LOWER(?)", true, payee).Find(&bills).Error
+	return bills, err
+}
+
+// UpdateBill mengunci row tagihan, menjalankan fn untuk mengubahnya, lalu menyimpan
+func UpdateBill(id uint, fn func(b *models.Bill) error) (*models.Bill, error) {
+	var b models.Bill
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&b, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBillNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(&b); err != nil {
+			return err
+		}
+		return tx.Save(&b).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func GetBill(id uint) (*models.Bill, error) {
+	var b models.Bill
+	err := DB.First(&b, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBillNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// DeleteBill menghapus tagihan beserta riwayat pembayaran dan pengingatnya
+func DeleteBill(id uint) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&models.Bill{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrBillNotFound
+		}
+		if err := tx.Where("bill_id = ?", id).Delete(&models.BillPayment{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("bill_id = ?", id).Delete(&models.BillReminder{}).Error
+	})
+}
+
+// BillPayments mengambil pembayaran tagihan-tagihan pada periode tertentu, key bill_id lalu periode
+func BillPayments(billIDs []uint, periods []string) (map[uint]map[string]models.BillPayment, error) {
+	result := map[uint]map[string]models.BillPayment{}
+	if len(billIDs) == 0 || len(periods) == 0 {
+		return result, nil
+	}
+
+	var list []models.BillPayment
+	if err := DB.Where("bill_id IN ? AND period IN ?", billIDs, periods).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range list {
+		if result[p.BillID] == nil {
+			result[p.BillID] = map[string]models.BillPayment{}
+		}
+		result[p.BillID][p.Period] = p
+	}
+	return result, nil
+}
+
+// RecentBillAmounts mengambil nominal n pembayaran terakhir, untuk perkiraan tagihan estimated
+func RecentBillAmounts(billID uint, n int) ([]float64, error) {
+	var amounts []float64
+	err := DB.Model(&models.BillPayment{}).Where("bill_id = ?", billID).
+		Order("period DESC").Limit(n).Pluck("amount", &amounts).Error
+	return amounts, err
+}
+
+// SaveBillPayment mencatat pembayaran satu periode. Jika TransactionID diisi, transaksinya
+// dikunci dan harus pengeluaran approved yang belum dipakai pembayaran lain. Mengembalikan
+// ErrBillPaymentExists jika periode itu sudah dibayar.
+func SaveBillPayment(p *models.BillPayment) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if p.TransactionID != nil {
+			if _, err := lockLinkableTransaction(tx, *p.TransactionID, "pengeluaran"); err != nil {
+				return err
+			}
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(p)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrBillPaymentExists
+		}
+		return nil
+	})
+}
+
+// DeleteBillPayment membatalkan pembayaran tagihan satu periode
+func DeleteBillPayment(billID uint, period string) error {
+	res := DB.Where("bill_id = ? AND period = ?", billID, period).Delete(&models.BillPayment{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrBillPaymentNotFound
+	}
+	return nil
+}
+
+// RecordBillReminder mencatat pengingat; false jika pengingat yang sama sudah pernah dikirim
+func RecordBillReminder(r *models.BillReminder) (bool, error) {
+	res := DB.Clauses(clause.OnConflict{DoNothing: true}).Create(r)
+	return res.RowsAffected > 0, res.Error
+}
+
+// UnlinkBillTransaction dipanggil saat transaksi dihapus: pembayaran tagihan dari transaksi
+// itu dibatalkan supaya periodenya kembali belum dibayar
+func UnlinkBillTransaction(tx *gorm.DB, transactionID uint) error {
+	return tx.Where("transaction_id = ?", transactionID).Delete(&models.BillPayment{}).Error
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCampaignNotFound dikembalikan jika campaign dengan ID tersebut tidak ada
+var ErrCampaignNotFound = errors.New("campaign tidak ditemukan")
+
+// CreateCampaign menyimpan campaign baru. Campaign lain tidak dinonaktifkan,
+// jadi beberapa campaign bisa antre dan tayang bergantian sesuai StartAt.
+func CreateCampaign(c *models.Campaign) error {
+	return DB.Create(c).Error
+}
+
+// ActiveCampaigns mengambil semua campaign yang sedang tayang di placement tersebut
+// dan cocok dengan targeting role / workspace user. Campaign tanpa target tampil untuk semua.
+// Hasil diurutkan prioritas tertinggi dulu; pemilihan berbobot dilakukan oleh pemanggil.
+func ActiveCampaigns(placement, role, workspace string, now time.Time) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+	err := DB.
+		Where("is_active = ? AND start_at <= ? AND end_at > ? AND placement = ?", true, now, now, placement).
+		Where("(COALESCE(cardinality(target_roles), 0) = 0 OR ? = ANY(target_roles))", role).
+		Where("(COALESCE(cardinality(target_workspaces), 0) = 0 OR ? = ANY(target_workspaces))", workspace).
+		Order("priority DESC, start_at DESC").
+		Find(&campaigns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Jadwal berulang bergantung pada zona waktu masing-masing campaign, jadi dicek di Go
+	live := campaigns[:0]
+	for _, c := range campaigns {
+		if c.InRecurringWindow(now) {
+			live = append(live, c)
+		}
+	}
+	return live, nil
+}
+
+// ListCampaigns mengambil semua campaign, opsional difilter status (scheduled, live, expired, disabled) dan placement
+func ListCampaigns(status, placement string, now time.Time) ([]models.Campaign, error) {
+	q := DB.Model(&models.Campaign{})
+	if placement != "" {
+		q = q.Where("placement = ?", placement)
+	}
+	switch status {
+	case models.CampaignDisabled:
+		q = q.Where("is_active = ?", false)
+	case models.CampaignScheduled:
+		q = q.Where("is_active = ? AND end_at > ?", true, now)
+	case models.CampaignLive:
+		q = q.Where("is_active = ? AND start_at <= ? AND end_at > ?", true, now, now)
+	case models.CampaignExpired:
+		q = q.Where("is_active = ? AND end_at <= ?", true, now)
+	}
+
+	var campaigns []models.Campaign
+	if err := q.Order("start_at ASC").Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+	if status == "" {
+		return campaigns, nil
+	}
+
+	// Query di atas hanya saringan kasar; status final memperhitungkan jadwal berulang
+	filtered := campaigns[:0]
+	for _, c := range campaigns {
+		if c.StatusAt(now) == status {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+func GetCampaign(id uint) (*models.Campaign, error) {
+	var c models.Campaign
+	err := DB.First(&c, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// UpdateCampaign mengunci row campaign, menjalankan fn untuk mengubahnya, lalu menyimpan.
+// Row lock membuat update dari beberapa replica API tetap berurutan.
+func UpdateCampaign(id uint, fn func(c *models.Campaign) error) (*models.Campaign, error) {
+	var c models.Campaign
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&c, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCampaignNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(&c); err != nil {
+			return err
+		}
+		return tx.Save(&c).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// DeleteCampaign menghapus campaign beserta statistiknya dan mengembalikan data terakhirnya (untuk hapus file gambar)
+func DeleteCampaign(id uint) (*models.Campaign, error) {
+	var c models.Campaign
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&c, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCampaignNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := deleteCampaignStats(tx, c.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&c).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
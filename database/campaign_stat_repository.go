@@ -0,0 +1,64 @@
+package db
+
+import (
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordCampaignEvent mencatat impression / click. Event dari viewer yang sama di hari yang sama
+// hanya dihitung sekali. Mengembalikan true jika event dihitung.
+func RecordCampaignEvent(campaignID uint, day time.Time, kind, viewerKey string) (bool, error) {
+	counted := false
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.CampaignViewer{
+			CampaignID: campaignID,
+			Day:        day,
+			Kind:       kind,
+			ViewerKey:  viewerKey,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		stat := models.CampaignDailyStat{CampaignID: campaignID, Day: day}
+		column := "impressions"
+		if kind == models.CampaignEventClick {
+			column = "clicks"
+			stat.Clicks = 1
+		} else {
+			stat.Impressions = 1
+		}
+
+		// Upsert counter secara atomik supaya aman dipanggil dari banyak replica
+		counted = true
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "campaign_id"}, {Name: "day"}},
+			DoUpdates: clause.Set{{Column: clause.Column{Name: column}, Value: gorm.Expr("campaign_daily_stats." + column + " + 1")}},
+		}).Create(&stat).Error
+	})
+	return counted && err == nil, err
+}
+
+// CampaignDailyStats mengambil counter harian campaign di rentang tanggal [from, to]
+func CampaignDailyStats(campaignID uint, from, to time.Time) ([]models.CampaignDailyStat, error) {
+	var stats []models.CampaignDailyStat
+	err := DB.
+		Where("campaign_id = ? AND day BETWEEN ? AND ?", campaignID, from, to).
+		Order("day ASC").
+		Find(&stats).Error
+	return stats, err
+}
+
+func deleteCampaignStats(tx *gorm.DB, campaignID uint) error {
+	if err := tx.Where("campaign_id = ?", campaignID).Delete(&models.CampaignViewer{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("campaign_id = ?", campaignID).Delete(&models.CampaignDailyStat{}).Error
+}
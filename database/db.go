@@ -30,7 +30,7 @@ func Init() {
 	}
 
 	// if os.Getenv("ENV") != "production" {
-	DB.AutoMigrate(&models.Transaction{})
+	DB.AutoMigrate(&models.Transaction{}, &models.ApprovalHistory{}, &models.Attachment{}, &models.Campaign{}, &models.CampaignDailyStat{}, &models.CampaignViewer{}, &models.RecurringTransaction{}, &models.Anomaly{}, &models.AnomalyScan{}, &models.DailySummary{}, &models.DailyCategorySummary{}, &models.WebhookSubscription{}, &models.WebhookDelivery{}, &models.WebhookAttempt{}, &models.NetWorthItem{}, &models.NetWorthValuation{}, &models.Debt{}, &models.DebtRepayment{}, &models.InstallmentPlan{}, &models.Installment{}, &models.Bill{}, &models.BillPayment{}, &models.BillReminder{}, &models.Notification{}, &models.NotificationPreference{}, &models.NotificationRoute{})
 	// }
 
 }
@@ -0,0 +1,298 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrDebtNotFound dikembalikan jika hutang piutang dengan ID tersebut tidak ada
+	ErrDebtNotFound = errors.New("hutang piutang tidak ditemukan")
+	// ErrRepaymentNotFound dikembalikan jika pembayaran dengan ID tersebut tidak ada
+	ErrRepaymentNotFound = errors.New("pembayaran tidak ditemukan")
+	// ErrDebtClosed dikembalikan saat menambah pembayaran ke hutang piutang yang sudah lunas / diikhlaskan
+	ErrDebtClosed = errors.New("hutang piutang sudah ditutup")
+)
+
+// DebtValidationError menjelaskan kenapa perubahan hutang piutang ditolak (misal pembayaran
+// melebihi sisa); handler mengembalikannya sebagai 400
+type DebtValidationError struct {
+	Reason string
+}
+
+func (e *DebtValidationError) Error() string {
+	return e.Reason
+}
+
+// DebtFilter membatasi ListDebts. OverdueAt diisi untuk hanya mengambil yang lewat jatuh tempo.
+type DebtFilter struct {
+	Direction    string
+	Status       string
+	Counterparty string
+	OverdueAt    *time.Time
+}
+
+// ListDebts mengambil hutang piutang sesuai filter, jatuh tempo terdekat dulu
+func ListDebts(f DebtFilter) ([]models.Debt, error) {
+	q := DB.Model(&models.Debt{})
+	if f.Direction != "" {
+		q = q.Where("direction = ?", f.Direction)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.Counterparty != "" {
+		q = q.Where("LOWER(counterparty) = LOWER(?)", f.Counterparty)
+	}
+	if f.OverdueAt != nil {
+		q = q.Where("status = ? AND due_date < ?", models.DebtOpen, *f.OverdueAt)
+	}
+
+	var debts []models.Debt
+	err := q.Order("due_date ASC NULLS LAST, start_date ASC").Find(&debts).Error
+	return debts, err
+}
+
+// GetDebt mengambil hutang piutang beserta riwayat pembayarannya
+func GetDebt(id uint) (*models.Debt, error) {
+	var d models.Debt
+	err := DB.Preload("Repayments", func(tx *gorm.DB) *gorm.DB { return tx.Order("date ASC, id ASC") }).
+		First(&d, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDebtNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// linkTransaction menandai transaksi sebagai bagian dari hutang piutang. Transaksi harus
+// approved, bertipe wantType dan belum terhubung ke pembayaran lain.
+func linkTransaction(tx *gorm.DB, transactionID, debtID uint, wantType string) (models.Transaction, error) {
+	t, err := lockLinkableTransaction(tx, transactionID, wantType)
+	if err != nil {
+		return t, err
+	}
+	return t, tx.Model(&t).Update("debt_id", debtID).Error
+}
+
+// refreshDebtLinks menghitung ulang rollup hari-hari transaksi approved yang tanda hutang
+// piutangnya berubah, karena transaksi hutang piutang tidak dihitung di dashboard.
+// Mengembalikan waktu transaksinya untuk invalidasi cache dashboard setelah commit.
+func refreshDebtLinks(tx *gorm.DB, loc *time.Location, txs ...models.Transaction) ([]time.Time, error) {
+	var changed, days []time.Time
+	for _, t := range txs {
+		if t.Status != models.StatusApproved {
+			continue
+		}
+		changed = append(changed, t.TransactionAt)
+		days = append(days, t.TransactionAt.In(loc))
+	}
+	return changed, RefreshDailySummaries(tx, loc, days...)
+}
+
+// CreateDebt menyimpan hutang piutang baru dan menghubungkan transaksi pencairannya jika ada
+// (piutang: pengeluaran saat meminjamkan, hutang: pemasukan saat menerima pinjaman).
+// loc adalah zona rollup; waktu transaksi yang rollup-nya berubah dikembalikan.
+func CreateDebt(d *models.Debt, loc *time.Location) ([]time.Time, error) {
+	var changed []time.Time
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(d).Error; err != nil {
+			return err
+		}
+		if d.TransactionID == nil {
+			return nil
+		}
+		t, err := linkTransaction(tx, *d.TransactionID, d.ID, disbursementType(d.Direction))
+		if err != nil {
+			return err
+		}
+		changed, err = refreshDebtLinks(tx, loc, t)
+		return err
+	})
+	return changed, err
+}
+
+// UpdateDebt mengunci row hutang piutang, menjalankan fn untuk mengubahnya, lalu menyimpan
+func UpdateDebt(id uint, fn func(d *models.Debt) error) (*models.Debt, error) {
+	var d models.Debt
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&d, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDebtNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(&d); err != nil {
+			return err
+		}
+		return tx.Save(&d).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// DeleteDebt menghapus hutang piutang beserta pembayarannya. Transaksi yang terhubung
+// tidak dihapus, hanya dilepas tandanya sehingga kembali dihitung di dashboard.
+func DeleteDebt(id uint, loc *time.Location) ([]time.Time, error) {
+	var changed []time.Time
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&models.Debt{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDebtNotFound
+		}
+		if err := tx.Where("debt_id = ?", id).Delete(&models.DebtRepayment{}).Error; err != nil {
+			return err
+		}
+
+		var linked []models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("debt_id = ?", id).Find(&linked).Error; err != nil {
+			return err
+		}
+		if len(linked) == 0 {
+			return nil
+		}
+		if err := tx.Model(&models.Transaction{}).Where("debt_id = ?", id).Update("debt_id", nil).Error; err != nil {
+			return err
+		}
+		var err error
+		changed, err = refreshDebtLinks(tx, loc, linked...)
+		return err
+	})
+	return changed, err
+}
+
+// AddRepayment mencatat pembayaran, menambah Repaid dan menandai lunas jika sisa habis.
+// Row hutang dikunci supaya dua pembayaran bersamaan tidak melebihi sisa.
+func AddRepayment(debtID uint, rep *models.DebtRepayment, loc *time.Location) (*models.Debt, []time.Time, error) {
+	var d models.Debt
+	var changed []time.Time
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&d, debtID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDebtNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if d.Status != models.DebtOpen {
+			return ErrDebtClosed
+		}
+		if rep.Amount > d.Outstanding() {
+			return &DebtValidationError{fmt.Sprintf("amount melebihi sisa %.0f", d.Outstanding())}
+		}
+
+		rep.DebtID = d.ID
+		if err := tx.Create(rep).Error; err != nil {
+			return err
+		}
+		if rep.TransactionID != nil {
+			t, err := linkTransaction(tx, *rep.TransactionID, d.ID, repaymentType(d.Direction))
+			if err != nil {
+				return err
+			}
+			if changed, err = refreshDebtLinks(tx, loc, t); err != nil {
+				return err
+			}
+		}
+
+		d.Repaid += rep.Amount
+		if d.Outstanding() == 0 {
+			d.Status = models.DebtPaid
+		}
+		return tx.Save(&d).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &d, changed, nil
+}
+
+// DeleteRepayment membatalkan pembayaran; hutang yang tadinya lunas dibuka lagi dan
+// transaksinya kembali dihitung di dashboard
+func DeleteRepayment(id uint, loc *time.Location) (*models.Debt, []time.Time, error) {
+	var d models.Debt
+	var changed []time.Time
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var rep models.DebtRepayment
+		err := tx.First(&rep, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRepaymentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&d, rep.DebtID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&rep).Error; err != nil {
+			return err
+		}
+		if rep.TransactionID != nil {
+			var t models.Transaction
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&t, *rep.TransactionID).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil {
+				if err := tx.Model(&t).Update("debt_id", nil).Error; err != nil {
+					return err
+				}
+				if changed, err = refreshDebtLinks(tx, loc, t); err != nil {
+					return err
+				}
+			}
+		}
+
+		d.Repaid = max(d.Repaid-rep.Amount, 0)
+		if d.Status == models.DebtPaid && d.Outstanding() > 0 {
+			d.Status = models.DebtOpen
+		}
+		return tx.Save(&d).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &d, changed, nil
+}
+
+// UnlinkDebtTransaction dipanggil saat transaksi dihapus: pembayaran dan hutang piutang
+// tetap ada, hanya referensi ke transaksinya yang dilepas
+func UnlinkDebtTransaction(tx *gorm.DB, transactionID uint) error {
+	if err := tx.Model(&models.DebtRepayment{}).Where("transaction_id = ?", transactionID).
+		Update("transaction_id", nil).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Debt{}).Where("transaction_id = ?", transactionID).
+		Update("transaction_id", nil).Error
+}
+
+// disbursementType adalah tipe transaksi saat uang pinjaman berpindah
+func disbursementType(direction string) string {
+	if direction == models.DebtReceivable {
+		return "pengeluaran"
+	}
+	return "pemasukan"
+}
+
+// repaymentType adalah tipe transaksi saat pinjaman dibayar kembali
+func repaymentType(direction string) string {
+	if direction == models.DebtReceivable {
+		return "pemasukan"
+	}
+	return "pengeluaran"
+}
@@ -0,0 +1,264 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrPlanNotFound dikembalikan jika plan cicilan dengan ID tersebut tidak ada
+	ErrPlanNotFound = errors.New("plan cicilan tidak ditemukan")
+	// ErrNoPendingInstallment dikembalikan jika tidak ada cicilan yang bisa dibayar
+	ErrNoPendingInstallment = errors.New("tidak ada cicilan yang belum dibayar")
+	// ErrInstallmentNotPaid dikembalikan saat membatalkan pembayaran cicilan yang belum dibayar
+	ErrInstallmentNotPaid = errors.New("cicilan tersebut belum dibayar")
+)
+
+// Pencocokan otomatis: cicilan yang jatuh tempo paling lambat sebulan setelah transaksi,
+// dengan selisih nominal maksimal 1% (minimal Rp1.000) untuk pembulatan dari bank / aplikasi.
+const (
+	installmentMatchAhead   = 31 * 24 * time.Hour
+	installmentMatchMinDiff = 1000
+)
+
+func orderedInstallments(tx *gorm.DB) *gorm.DB {
+	return tx.Order("number ASC")
+}
+
+// CreateInstallmentPlan menyimpan plan beserta seluruh jadwal cicilannya
+func CreateInstallmentPlan(p *models.InstallmentPlan) error {
+	p.Installments = p.Schedule()
+	return DB.Create(p).Error
+}
+
+// ListInstallmentPlans mengambil plan (opsional difilter status) beserta jadwalnya
+func ListInstallmentPlans(status string) ([]models.InstallmentPlan, error) {
+	q := DB.Preload("Installments", orderedInstallments).Order("first_due_date ASC, id ASC")
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var plans []models.InstallmentPlan
+	err := q.Find(&plans).Error
+	return plans, err
+}
+
+func GetInstallmentPlan(id uint) (*models.InstallmentPlan, error) {
+	var p models.InstallmentPlan
+	err := DB.Preload("Installments", orderedInstallments).First(&p, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SetInstallmentPlanStatus mengubah status plan (misal cancelled saat dilunasi dipercepat)
+func SetInstallmentPlanStatus(id uint, status string) error {
+	res := DB.Model(&models.InstallmentPlan{}).Where("id = ?", id).Update("status", status)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrPlanNotFound
+	}
+	return nil
+}
+
+// DeleteInstallmentPlan menghapus plan dan jadwalnya; transaksi pembayaran tetap ada
+func DeleteInstallmentPlan(id uint) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&models.InstallmentPlan{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrPlanNotFound
+		}
+		installments := tx.Model(&models.Installment{}).Select("id").Where("plan_id = ?", id)
+		if err := tx.Model(&models.Transaction{}).Where("installment_id IN (?)", installments).
+			Update("installment_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("plan_id = ?", id).Delete(&models.Installment{}).Error
+	})
+}
+
+// markPaid menandai cicilan lunas, menghubungkan transaksinya jika ada (harus pengeluaran
+// approved yang belum dipakai pembayaran lain), dan menyelesaikan plan jika semua cicilan
+// sudah dibayar
+func markPaid(tx *gorm.DB, inst *models.Installment, transactionID *uint, paidAt time.Time) error {
+	if transactionID != nil {
+		t, err := lockLinkableTransaction(tx, *transactionID, "pengeluaran")
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&t).Update("installment_id", inst.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	inst.Status = models.InstallmentPaid
+	inst.PaidAt = &paidAt
+	inst.TransactionID = transactionID
+	if err := tx.Save(inst).Error; err != nil {
+		return err
+	}
+
+	var pending int64
+	if err := tx.Model(&models.Installment{}).
+		Where("plan_id = ? AND status = ?", inst.PlanID, models.InstallmentPending).
+		Count(&pending).Error; err != nil {
+		return err
+	}
+	if pending > 0 {
+		return nil
+	}
+	return tx.Model(&models.InstallmentPlan{}).Where("id = ? AND status = ?", inst.PlanID, models.PlanActive).
+		Update("status", models.PlanCompleted).Error
+}
+
+// PayInstallment menandai cicilan nomor number (0 = cicilan belum dibayar paling awal) lunas
+func PayInstallment(planID uint, number int, transactionID *uint, paidAt time.Time) (*models.Installment, error) {
+	var inst models.Installment
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var plan models.InstallmentPlan
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&plan, planID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPlanNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		q := tx.Where("plan_id = ? AND status = ?", planID, models.InstallmentPending)
+		if number > 0 {
+			q = q.Where("number = ?", number)
+		}
+		err = q.Order("number ASC").First(&inst).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoPendingInstallment
+		}
+		if err != nil {
+			return err
+		}
+		return markPaid(tx, &inst, transactionID, paidAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
+
+// MatchInstallment mencari cicilan yang dibayar oleh transaksi t: plan aktif dengan payee
+// (dan account, jika plan mengisinya) yang sama dan nominal yang cocok, jatuh tempo paling awal.
+// Mengembalikan nil jika tidak ada yang cocok.
+func MatchInstallment(t models.Transaction) (*models.Installment, error) {
+	if t.Payee == "" || t.InstallmentID != nil || t.DebtID != nil {
+		return nil, nil
+	}
+	tolerance := math.Max(installmentMatchMinDiff, t.Amount/100)
+
+	var matched *models.Installment
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var inst models.Installment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "installments"}, Options: "SKIP LOCKED"}).
+			Joins("JOIN installment_plans ON installment_plans.id = installments.plan_id").
+			Where("installment_plans.status = ? AND installments.status = ?", models.PlanActive, models.InstallmentPending).
+			Where("LOWER(installment_plans.payee) = LOWER(?)", t.Payee).
+			Where("(installment_plans.account = '' OR installment_plans.account = ?)", t.Account).
+			Where("ABS(installments.amount - ?) <= ?", t.Amount, tolerance).
+			Where("installments.due_date <= ?", t.TransactionAt.Add(installmentMatchAhead)).
+			Order("installments.due_date ASC, installments.id ASC").
+			First(&inst).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = markPaid(tx, &inst, &t.ID, t.TransactionAt)
+		var invalid *TransactionLinkError
+		if errors.As(err, &invalid) {
+			return nil
+		}
+		if err == nil {
+			matched = &inst
+		}
+		return err
+	})
+	return matched, err
+}
+
+// UnpayInstallment membatalkan pembayaran cicilan (plan yang sudah selesai aktif lagi)
+func UnpayInstallment(tx *gorm.DB, inst models.Installment) error {
+	if inst.TransactionID != nil {
+		if err := tx.Model(&models.Transaction{}).Where("id = ?", *inst.TransactionID).
+			Update("installment_id", nil).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&inst).Updates(map[string]interface{}{
+		"status":         models.InstallmentPending,
+		"paid_at":        nil,
+		"transaction_id": nil,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.InstallmentPlan{}).Where("id = ? AND status = ?", inst.PlanID, models.PlanCompleted).
+		Update("status", models.PlanActive).Error
+}
+
+// UnpayInstallmentNumber membatalkan pembayaran cicilan nomor number pada plan
+func UnpayInstallmentNumber(planID uint, number int) (*models.Installment, error) {
+	var inst models.Installment
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("plan_id = ? AND number = ? AND status = ?", planID, number, models.InstallmentPaid).
+			First(&inst).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInstallmentNotPaid
+		}
+		if err != nil {
+			return err
+		}
+		return UnpayInstallment(tx, inst)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
+
+// UnlinkInstallmentTransaction dipanggil saat transaksi dihapus: cicilan yang dibayar
+// oleh transaksi itu kembali belum dibayar
+func UnlinkInstallmentTransaction(tx *gorm.DB, transactionID uint) error {
+	var list []models.Installment
+	if err := tx.Where("transaction_id = ?", transactionID).Find(&list).Error; err != nil {
+		return err
+	}
+	for _, inst := range list {
+		inst.TransactionID = nil // transaksinya sudah dihapus
+		if err := UnpayInstallment(tx, inst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PendingInstallmentPlans mengambil plan aktif beserta cicilan belum dibayar yang jatuh tempo sampai to
+func PendingInstallmentPlans(to time.Time) ([]models.InstallmentPlan, error) {
+	var plans []models.InstallmentPlan
+	err := DB.Preload("Installments", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("status = ? AND due_date <= ?", models.InstallmentPending, to).Order("number ASC")
+	}).Where("status = ?", models.PlanActive).Find(&plans).Error
+	return plans, err
+}
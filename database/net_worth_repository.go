@@ -0,0 +1,61 @@
+package db
+
+import (
+	"errors"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNetWorthItemNotFound dikembalikan jika item net worth dengan ID tersebut tidak ada
+var ErrNetWorthItemNotFound = errors.New("item net worth tidak ditemukan")
+
+func orderedValuations(tx *gorm.DB) *gorm.DB {
+	return tx.Order("date ASC")
+}
+
+// ListNetWorthItems mengambil semua item beserta penilaiannya (urut tanggal)
+func ListNetWorthItems() ([]models.NetWorthItem, error) {
+	var items []models.NetWorthItem
+	err := DB.Preload("Valuations", orderedValuations).Order("kind ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+func GetNetWorthItem(id uint) (*models.NetWorthItem, error) {
+	var item models.NetWorthItem
+	err := DB.Preload("Valuations", orderedValuations).First(&item, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNetWorthItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DeleteNetWorthItem menghapus item beserta semua penilaiannya
+func DeleteNetWorthItem(id uint) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item_id = ?", id).Delete(&models.NetWorthValuation{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&models.NetWorthItem{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNetWorthItemNotFound
+		}
+		return nil
+	})
+}
+
+// SaveValuation mencatat nilai item pada tanggal v.Date; jika tanggal itu sudah ada, nilainya ditimpa
+func SaveValuation(v *models.NetWorthValuation) error {
+	return DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "item_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "note", "updated_at"}),
+	}).Create(v).Error
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNotificationNotFound dikembalikan jika notifikasi tidak ada atau milik user lain
+	ErrNotificationNotFound = errors.New("notifikasi tidak ditemukan")
+	// ErrPreferenceNotFound dikembalikan jika user belum pernah menyimpan preferensi notifikasi
+	ErrPreferenceNotFound = errors.New("preferensi notifikasi belum diatur")
+)
+
+func GetNotificationPreference(userID string) (*models.NotificationPreference, error) {
+	var p models.NotificationPreference
+	err := DB.Preload("Routes").Where("user_id = ?", userID).First(&p).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPreferenceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SaveNotificationPreference menyimpan preferensi user (upsert per user_id) dan mengganti
+// seluruh routes-nya dengan p.Routes
+func SaveNotificationPreference(p *models.NotificationPreference) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		routes := p.Routes
+		p.Routes = nil
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"workspace", "role", "email", "webhook_url", "updated_at"}),
+		}).Create(p).Error
+		if err != nil {
+			return err
+		}
+		// RETURNING dari upsert mengisi p.ID, baik row baru maupun yang sudah ada
+		if err := tx.Where("preference_id = ?", p.ID).Delete(&models.NotificationRoute{}).Error; err != nil {
+			return err
+		}
+		for i := range routes {
+			routes[i].ID = 0
+			routes[i].PreferenceID = p.ID
+		}
+		p.Routes = routes
+		if len(routes) == 0 {
+			return nil
+		}
+		return tx.Create(&routes).Error
+	})
+}
+
+// NotificationRecipients mengambil preferensi user yang punya route untuk tipe event,
+// routes-nya hanya yang untuk event itu
+func NotificationRecipients(event string) ([]models.NotificationPreference, error) {
+	var list []models.NotificationPreference
+	err := DB.Preload("Routes", "event = ?", event).
+		Where("id IN (?)", DB.Model(&models.NotificationRoute{}).Select("preference_id").Where("event = ?", event)).
+		Find(&list).Error
+	return list, err
+}
+
+func CreateNotification(n *models.Notification) error {
+	return DB.Create(n).Error
+}
+
+// ListNotifications mengambil inbox user, terbaru di atas, beserta jumlah yang belum dibaca
+func ListNotifications(userID string, unreadOnly bool, limit int) (models.NotificationInbox, error) {
+	inbox := models.NotificationInbox{Items: []models.Notification{}}
+	if err := DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&inbox.Unread).Error; err != nil {
+		return inbox, err
+	}
+
+	q := DB.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Limit(limit)
+	if unreadOnly {
+		q = q.Where("read_at IS NULL")
+	}
+	err := q.Find(&inbox.Items).Error
+	return inbox, err
+}
+
+// MarkNotificationRead menandai notifikasi milik user sudah dibaca (idempotent)
+func MarkNotificationRead(userID string, id uint) error {
+	res := DB.Model(&models.Notification{}).Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead menandai seluruh inbox user sudah dibaca, mengembalikan jumlahnya
+func MarkAllNotificationsRead(userID string) (int64, error) {
+	res := DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return res.RowsAffected, res.Error
+}
+
+func DeleteNotification(userID string, id uint) error {
+	res := DB.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Notification{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+)
+
+// Kolom agregat yang sama untuk kedua tabel rollup. Semua hanya menghitung transaksi approved
+// yang bukan pencairan / pembayaran hutang piutang (debt_id kosong).
+const (
+	summarySelect = `type, COALESCE(category, ''), COALESCE(account, ''), SUM(amount), COUNT(*)
+		FROM transactions`
+	categorySummarySelect = `type, c, COALESCE(account, ''), SUM(amount), COUNT(*)
+		FROM transactions, unnest(CASE WHEN COALESCE(cardinality(categories), 0) > 0
+			THEN categories ELSE ARRAY[COALESCE(category, '')] END) AS c`
+)
+
+var summaryTables = []struct{ table, selectSQL string }{
+	{"daily_summaries", summarySelect},
+	{"daily_category_summaries", categorySummarySelect},
+}
+
+// RefreshDailySummaries menghitung ulang rollup untuk hari-hari tertentu (tanggal di zona loc)
+// dari tabel transactions. Dipanggil di dalam DB transaction yang sama dengan perubahan
+// transaksi, sehingga rollup selalu konsisten dengan data mentah.
+func RefreshDailySummaries(conn *gorm.DB, loc *time.Location, days ...time.Time) error {
+	seen := map[string]bool{}
+	for _, d := range days {
+		day := d.Format("2006-01-02")
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+
+		start := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 0, 1)
+
+		// Kunci per hari supaya dua request yang menyentuh hari yang sama tidak saling tabrak
+		if err := conn.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "daily_summary:"+day).Error; err != nil {
+			return err
+		}
+
+		for _, t := range summaryTables {
+			if err := conn.Exec("DELETE FROM "+t.table+" WHERE day = ?::date", day).Error; err != nil {
+				return err
+			}
+			err := conn.Exec(fmt.Sprintf(`INSERT INTO %s (day, type, category, account, total, count)
+				SELECT ?::date, %s
+				WHERE status = ? AND debt_id IS NULL AND transaction_at >= ? AND transaction_at < ?
+				GROUP BY 2, 3, 4`, t.table, t.selectSQL),
+				day, models.StatusApproved, start, end).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RebuildDailySummaries mengisi ulang seluruh rollup dari nol
+func RebuildDailySummaries(conn *gorm.DB, loc *time.Location) error {
+	dayExpr := fmt.Sprintf("(transaction_at AT TIME ZONE '%s')::date", loc.String())
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		for _, t := range summaryTables {
+			if err := tx.Exec("LOCK TABLE " + t.table + " IN EXCLUSIVE MODE").Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM " + t.table).Error; err != nil {
+				return err
+			}
+			err := tx.Exec(fmt.Sprintf(`INSERT INTO %s (day, type, category, account, total, count)
+				SELECT %s AS day, %s
+				WHERE status = ? AND debt_id IS NULL
+				GROUP BY 1, 2, 3, 4`, t.table, dayExpr, t.selectSQL),
+				models.StatusApproved).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// EnsureDailySummaries membangun rollup jika tabelnya masih kosong padahal sudah ada
+// transaksi approved (misal pertama kali deploy setelah tabel rollup ditambahkan).
+func EnsureDailySummaries(conn *gorm.DB, loc *time.Location) error {
+	var empty bool
+	err := conn.Raw(`SELECT NOT EXISTS (SELECT 1 FROM daily_summaries)
+		AND EXISTS (SELECT 1 FROM transactions WHERE status = ?)`, models.StatusApproved).
+		Scan(&empty).Error
+	if err != nil || !empty {
+		return err
+	}
+	return RebuildDailySummaries(conn, loc)
+}
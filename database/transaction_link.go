@@ -0,0 +1,52 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransactionLinkError menjelaskan kenapa transaksi tidak bisa dicatat sebagai pembayaran
+// (tidak ditemukan, tipe / status salah, sudah dipakai); handler mengembalikannya sebagai 400
+type TransactionLinkError struct {
+	Reason string
+}
+
+func (e *TransactionLinkError) Error() string {
+	return e.Reason
+}
+
+// lockLinkableTransaction mengunci transaksi yang akan dihubungkan ke tagihan, cicilan atau
+// hutang piutang. Transaksi harus approved, bertipe wantType dan belum terhubung ke pembayaran
+// lain, supaya satu transaksi tidak terhitung dua kali.
+func lockLinkableTransaction(tx *gorm.DB, transactionID uint, wantType string) (models.Transaction, error) {
+	var t models.Transaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&t, transactionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return t, &TransactionLinkError{fmt.Sprintf("transaksi %d tidak ditemukan", transactionID)}
+	}
+	if err != nil {
+		return t, err
+	}
+	if t.Type != wantType {
+		return t, &TransactionLinkError{fmt.Sprintf("transaksi %d harus bertipe %s", transactionID, wantType)}
+	}
+	if t.Status != models.StatusApproved {
+		return t, &TransactionLinkError{fmt.Sprintf("transaksi %d belum approved", transactionID)}
+	}
+	if t.InstallmentID != nil || t.DebtID != nil {
+		return t, &TransactionLinkError{fmt.Sprintf("transaksi %d sudah terhubung ke cicilan atau hutang piutang", transactionID)}
+	}
+	var bills int64
+	if err := tx.Model(&models.BillPayment{}).Where("transaction_id = ?", transactionID).Count(&bills).Error; err != nil {
+		return t, err
+	}
+	if bills > 0 {
+		return t, &TransactionLinkError{fmt.Sprintf("transaksi %d sudah dipakai untuk pembayaran tagihan", transactionID)}
+	}
+	return t, nil
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrWebhookNotFound dikembalikan jika subscription webhook dengan ID tersebut tidak ada
+	ErrWebhookNotFound = errors.New("webhook tidak ditemukan")
+	// ErrDeliveryNotFound dikembalikan jika pengiriman webhook dengan ID tersebut tidak ada
+	ErrDeliveryNotFound = errors.New("pengiriman webhook tidak ditemukan")
+)
+
+// ListWebhooks mengambil subscription milik satu workspace
+func ListWebhooks(workspace string) ([]models.WebhookSubscription, error) {
+	var list []models.WebhookSubscription
+	err := DB.Where("workspace = ?", workspace).Order("id ASC").Find(&list).Error
+	return list, err
+}
+
+// ActiveWebhooks mengambil subscription aktif untuk dicocokkan dengan event baru
+func ActiveWebhooks() ([]models.WebhookSubscription, error) {
+	var list []models.WebhookSubscription
+	err := DB.Where("is_active = ?", true).Find(&list).Error
+	return list, err
+}
+
+func GetWebhook(id uint) (*models.WebhookSubscription, error) {
+	var s models.WebhookSubscription
+	err := DB.First(&s, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateWebhook mengunci row subscription, menjalankan fn untuk mengubahnya, lalu menyimpan
+func UpdateWebhook(id uint, fn func(s *models.WebhookSubscription) error) (*models.WebhookSubscription, error) {
+	var s models.WebhookSubscription
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&s, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWebhookNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(&s); err != nil {
+			return err
+		}
+		return tx.Save(&s).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// DeleteWebhook menghapus subscription beserta log pengirimannya
+func DeleteWebhook(id uint) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&models.WebhookSubscription{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrWebhookNotFound
+		}
+		deliveries := tx.Model(&models.WebhookDelivery{}).Select("id").Where("subscription_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&models.WebhookAttempt{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error
+	})
+}
+
+// ClaimDueDeliveries mengambil pengiriman pending yang sudah jatuh tempo dan memundurkan
+// NextAttemptAt sebesar lease, supaya replica lain tidak mengirim yang sama selagi request
+// masih berjalan. SKIP LOCKED membuat beberapa worker bisa jalan bersamaan.
+func ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var list []models.WebhookDelivery
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&list).Error
+		if err != nil || len(list) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(list))
+		for i, d := range list {
+			ids[i] = d.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return list, err
+}
+
+// RecordDeliveryAttempt menyimpan hasil satu percobaan kirim beserta status terbaru pengiriman
+func RecordDeliveryAttempt(d *models.WebhookDelivery, attempt models.WebhookAttempt) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		attempt.DeliveryID = d.ID
+		if err := tx.Create(&attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(d).Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+			Updates(d).Error
+	})
+}
+
+// ListDeliveries mengambil log pengiriman terbaru milik subscription di workspace tersebut.
+// subscriptionID 0 berarti semua subscription workspace itu.
+func ListDeliveries(subscriptionID uint, workspace, status string, limit int) ([]models.WebhookDelivery, error) {
+	q := DB.Order("webhook_deliveries.created_at DESC").Limit(limit)
+	if subscriptionID != 0 {
+		q = q.Where("subscription_id = ?", subscriptionID)
+	}
+	q = q.Where("subscription_id IN (?)",
+		DB.Model(&models.WebhookSubscription{}).Select("id").Where("workspace = ?", workspace))
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	var list []models.WebhookDelivery
+	err := q.Find(&list).Error
+	return list, err
+}
+
+// GetDelivery mengambil satu pengiriman beserta log percobaannya
+func GetDelivery(id uint) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := DB.Preload("Log", func(tx *gorm.DB) *gorm.DB { return tx.Order("created_at ASC") }).First(&d, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// RedeliverWebhook mengantrekan ulang pengiriman (termasuk yang sudah sukses atau dead)
+// dengan jatah retry baru. Log percobaan lama tetap disimpan.
+func RedeliverWebhook(id uint, now time.Time) (*models.WebhookDelivery, error) {
+	res := DB.Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          models.DeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+		"delivered_at":    nil,
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrDeliveryNotFound
+	}
+	return GetDelivery(id)
+}
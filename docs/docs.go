@@ -15,39 +15,84 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/campaigns": {
-            "post": {
-                "description": "Mengunggah campaign (dengan waktu mulai \u0026 akhir) dan menjadikannya aktif.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/api/anomalies": {
+            "get": {
+                "description": "Pengeluaran tidak biasa beserta penjelasannya: large_transaction (jauh di atas median kategori / payee), category_spike (pengeluaran kategori bulan ini \u003e= 3x median bulanan) dan price_increase (payee dengan harga stabil yang naik)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Campaign"
+                    "Anomalies"
                 ],
-                "summary": "Upload campaign baru dengan waktu aktif",
+                "summary": "Feed anomali pengeluaran",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Gambar campaign",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "large_transaction, category_spike atau price_increase",
+                        "name": "kind",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Waktu mulai campaign (format: 2006-01-02T15:04:05)",
-                        "name": "start_at",
-                        "in": "formData",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Ikut tampilkan yang sudah di-dismiss",
+                        "name": "include_dismissed",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Waktu akhir campaign (format: 2006-01-02T15:04:05)",
-                        "name": "end_at",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Jumlah maksimal (default 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Anomaly"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/anomalies/scan": {
+            "post": {
+                "description": "Sama dengan job periodik: memeriksa transaksi sejak scan terakhir dan lonjakan kategori bulan ini",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anomalies"
+                ],
+                "summary": "Jalankan scan anomali sekarang",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Anomaly"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/anomalies/{id}/dismiss": {
+            "post": {
+                "tags": [
+                    "Anomalies"
+                ],
+                "summary": "Dismiss anomali",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Anomaly ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -61,17 +106,52 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/approvals/mine": {
+            "get": {
+                "description": "Daftar transaksi user yang masih draft, menunggu approval, atau ditolak",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval"
+                ],
+                "summary": "Status pengajuan milik user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/models.TransactionResponse"
+                                }
+                            }
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -82,25 +162,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/campaigns/active": {
+        "/api/approvals/pending": {
             "get": {
-                "description": "Mendapatkan campaign yang sedang aktif berdasarkan waktu saat ini",
+                "description": "Daftar transaksi berstatus submitted, yang paling lama menunggu di atas",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Campaign"
+                    "Approval"
+                ],
+                "summary": "Antrian approval manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Harus manager",
+                        "name": "X-User-Role",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Ambil campaign yang aktif dan dalam rentang waktu",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.Campaign"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/models.TransactionResponse"
+                                }
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -111,94 +206,157 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/dashboard": {
-            "get": {
-                "description": "Menampilkan ringkasan transaksi",
-                "produces": [
-                    "application/json"
-                ],
+        "/api/attachments/{id}": {
+            "delete": {
                 "tags": [
-                    "Dashboard"
+                    "Attachments"
+                ],
+                "summary": "Hapus lampiran",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Dashboard utama",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/dashboard/bar": {
+        "/api/attachments/{id}/download": {
             "get": {
-                "description": "Menampilkan grafik batang pengeluaran per kategori",
+                "description": "Mengunduh file lampiran, hanya untuk pembuat transaksi atau manager",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Attachments"
+                ],
+                "summary": "Download lampiran",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Grafik batang pengeluaran",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/dashboard/donut": {
+        "/api/bills": {
             "get": {
-                "description": "Menampilkan grafik donat pemasukan per kategori",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Bills"
                 ],
-                "summary": "Grafik donat pemasukan",
+                "summary": "Daftar tagihan",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/models.Bill"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/api/dashboard/monthly-bar": {
-            "get": {
-                "description": "Menampilkan pengeluaran per kategori tiap bulan (maksimal 3 bulan terakhir)",
+            },
+            "post": {
+                "description": "amount_type fixed (nominal tetap) atau estimated (perkiraan; setelah ada pembayaran, perkiraan memakai rata-rata 3 pembayaran terakhir). Pengingat bill.upcoming dikirim reminder_days sebelum jatuh tempo, bill.due di hari jatuh tempo dan bill.overdue setelahnya, selama belum ada transaksi pembayaran.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Statistik"
+                    "Bills"
+                ],
+                "summary": "Tambah tagihan bulanan",
+                "parameters": [
+                    {
+                        "description": "Tagihan",
+                        "name": "bill",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BillRequest"
+                        }
+                    }
                 ],
-                "summary": "Statistik pengeluaran 3 bulan terakhir",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseWithMonths"
+                            "$ref": "#/definitions/models.Bill"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -209,33 +367,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/transactions": {
+        "/api/bills/upcoming": {
             "get": {
-                "description": "Menampilkan semua transaksi dengan filter dan pagination",
+                "description": "Untuk dashboard: tagihan belum dibayar yang jatuh tempo dalam days hari ke depan, ditambah yang sudah lewat jatuh tempo (sampai 60 hari ke belakang). Urut jatuh tempo.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Bills"
                 ],
-                "summary": "Ambil daftar transaksi",
+                "summary": "Tagihan yang akan datang dan yang terlewat",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit per page (default 10)",
-                        "name": "limit",
+                        "description": "Jumlah hari ke depan (default 30, maksimal 90)",
+                        "name": "days",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by type (pemasukan/pengeluaran)",
-                        "name": "type",
+                        "type": "boolean",
+                        "description": "Ikut tampilkan periode yang sudah dibayar",
+                        "name": "include_paid",
                         "in": "query"
                     }
                 ],
@@ -245,14 +397,25 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.TransactionResponse"
+                                "$ref": "#/definitions/models.UpcomingBill"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Menambahkan data transaksi",
+            }
+        },
+        "/api/bills/{id}": {
+            "put": {
+                "description": "Semua field opsional. is_active false menghentikan pengingat.",
                 "consumes": [
                     "application/json"
                 ],
@@ -260,33 +423,94 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Bills"
                 ],
-                "summary": "Tambah transaksi baru",
+                "summary": "Ubah tagihan",
                 "parameters": [
                     {
-                        "description": "Transaksi baru",
-                        "name": "transaction",
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Perubahan",
+                        "name": "bill",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Transaction"
+                            "$ref": "#/definitions/handlers.BillRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Transaction"
+                            "$ref": "#/definitions/models.Bill"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Riwayat pembayaran ikut dihapus; transaksinya tetap ada",
+                "tags": [
+                    "Bills"
+                ],
+                "summary": "Hapus tagihan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/transactions/top5": {
-            "get": {
-                "description": "Mendapatkan 5 transaksi terbaru berdasarkan tanggal dibuat (created_at DESC).",
+        "/api/bills/{id}/pay": {
+            "post": {
+                "description": "Untuk pembayaran yang tidak tercocokkan otomatis (payee berbeda, autodebet, dibayar orang lain). transaction_id harus pengeluaran approved yang belum dipakai tagihan, cicilan atau hutang piutang.",
                 "consumes": [
                     "application/json"
                 ],
@@ -294,24 +518,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Bills"
+                ],
+                "summary": "Tandai tagihan satu periode sudah dibayar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pembayaran",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BillPaymentRequest"
+                        }
+                    }
                 ],
-                "summary": "Get top 5 latest transactions",
                 "responses": {
-                    "200": {
-                        "description": "Daftar 5 transaksi terbaru",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.BillPayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/models.TransactionResponse"
-                                }
+                                "type": "string"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -322,20 +567,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/transactions/{id}": {
+        "/api/bills/{id}/payments/{period}": {
             "delete": {
-                "description": "Menghapus transaksi berdasarkan ID",
                 "tags": [
-                    "Transactions"
+                    "Bills"
                 ],
-                "summary": "Hapus transaksi",
+                "summary": "Batalkan pembayaran tagihan satu periode",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Bill ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Periode YYYY-MM",
+                        "name": "period",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -347,51 +598,5219 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.Campaign": {
+        },
+        "/api/campaigns": {
+            "get": {
+                "description": "Menampilkan semua campaign beserta statusnya (scheduled, live, expired, disabled), urut berdasarkan start_at",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Daftar semua campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status (scheduled/live/expired/disabled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter placement",
+                        "name": "placement",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Campaign"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mengunggah campaign (dengan waktu mulai \u0026 akhir). Campaign lain tidak dinonaktifkan, sehingga beberapa campaign bisa dijadwalkan antre.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Upload campaign baru dengan waktu aktif",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Gambar campaign (JPEG/PNG/WebP, maks 10MB dan 4096x4096)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu mulai campaign (2025-08-07T08:00:00+07:00, atau 2025-08-07T08:00:00 dalam timezone)",
+                        "name": "start_at",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir campaign (2025-08-14T08:00:00+07:00, atau 2025-08-14T08:00:00 dalam timezone)",
+                        "name": "end_at",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA campaign (default Asia/Jakarta)",
+                        "name": "timezone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hari tayang berulang, dipisah koma (sun,mon,tue,wed,thu,fri,sat)",
+                        "name": "recurrence_days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jam mulai slot berulang (HH:MM)",
+                        "name": "recurrence_start",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jam akhir slot berulang (HH:MM)",
+                        "name": "recurrence_end",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Placement (dashboard-top/transaction-list/splash, default dashboard-top)",
+                        "name": "placement",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Prioritas, angka lebih besar menang (default 0)",
+                        "name": "priority",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bobot rotasi antar campaign dengan prioritas sama (default 1)",
+                        "name": "weight",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role target, dipisah koma (kosong = semua)",
+                        "name": "target_roles",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace target, dipisah koma (kosong = semua)",
+                        "name": "target_workspaces",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL tujuan saat banner diklik",
+                        "name": "click_url",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/active": {
+            "get": {
+                "description": "Mendapatkan campaign yang sedang aktif di sebuah placement, sesuai targeting role / workspace user. Jika beberapa overlap, prioritas tertinggi menang lalu dirotasi berdasarkan weight.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Ambil campaign yang aktif dan dalam rentang waktu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Placement (default dashboard-top)",
+                        "name": "placement",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role user untuk targeting",
+                        "name": "X-User-Role",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace user untuk targeting",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Campaign"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Detail campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Campaign"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Semua field opsional. Jika gambar diganti, file lama dihapus dari storage.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Ubah jadwal, gambar, placement atau targeting campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Gambar campaign baru (JPEG/PNG/WebP, maks 10MB dan 4096x4096)",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu mulai campaign (RFC3339 dengan offset, atau waktu lokal dalam timezone)",
+                        "name": "start_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir campaign (RFC3339 dengan offset, atau waktu lokal dalam timezone)",
+                        "name": "end_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA campaign",
+                        "name": "timezone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hari tayang berulang, dipisah koma (kirim kosong untuk menghapus jadwal berulang)",
+                        "name": "recurrence_days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jam mulai slot berulang (HH:MM)",
+                        "name": "recurrence_start",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jam akhir slot berulang (HH:MM)",
+                        "name": "recurrence_end",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Placement (dashboard-top/transaction-list/splash)",
+                        "name": "placement",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Prioritas",
+                        "name": "priority",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bobot rotasi",
+                        "name": "weight",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role target, dipisah koma (kirim kosong untuk menghapus target)",
+                        "name": "target_roles",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace target, dipisah koma (kirim kosong untuk menghapus target)",
+                        "name": "target_workspaces",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL tujuan saat banner diklik",
+                        "name": "click_url",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus campaign beserta file gambarnya",
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Hapus campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/{id}/activate": {
+            "post": {
+                "description": "Campaign aktif akan tayang otomatis saat start_at tiba",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Aktifkan campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Campaign"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/{id}/click": {
+            "get": {
+                "description": "Mencatat klik (dedup per user / sesi per hari) lalu redirect ke click_url campaign",
+                "tags": [
+                    "Campaign Analytics"
+                ],
+                "summary": "Redirect klik campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/{id}/deactivate": {
+            "post": {
+                "description": "Campaign nonaktif tidak akan tayang meskipun masih dalam rentang waktu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Nonaktifkan campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Campaign"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/{id}/impression": {
+            "get": {
+                "description": "Mencatat impression (dedup per user / sesi per hari). GET mengembalikan GIF 1x1 untuk dipakai sebagai \u003cimg\u003e, POST mengembalikan 204.",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "Campaign Analytics"
+                ],
+                "summary": "Beacon impression campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mencatat impression (dedup per user / sesi per hari). GET mengembalikan GIF 1x1 untuk dipakai sebagai \u003cimg\u003e, POST mengembalikan 204.",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "Campaign Analytics"
+                ],
+                "summary": "Beacon impression campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/campaigns/{id}/stats": {
+            "get": {
+                "description": "Total impression, klik dan CTR (%) campaign selama StartAt - EndAt, beserta rincian harian",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign Analytics"
+                ],
+                "summary": "Statistik campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CampaignStatsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard": {
+            "get": {
+                "description": "Menampilkan ringkasan transaksi. Tanpa filter periode hanya 3 bulan terakhir yang dikembalikan di monthly_balance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Dashboard utama",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preset periode (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter type (pemasukan/pengeluaran)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/bar": {
+            "get": {
+                "description": "Menampilkan grafik batang pengeluaran per kategori (type bisa diganti lewat filter)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Grafik batang pengeluaran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preset periode (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter type (pemasukan/pengeluaran)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/breakdown": {
+            "get": {
+                "description": "Total dan persentase per category, account, payee, weekday atau hour (zona APP_TIMEZONE). Category memecah categories sehingga transaksi multi-kategori dihitung di tiap kategorinya dan persentase dihitung dari total irisan. Untuk category/account/payee irisan setelah top-N (default 5) digabung ke \"others\". Weekday dan hour diurutkan sesuai waktu kecuali top dikirim.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Breakdown pemasukan / pengeluaran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "category (default), account, payee, weekday atau hour",
+                        "name": "by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pemasukan atau pengeluaran (default pengeluaran)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah irisan sebelum digabung ke others (0 = semua)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset periode (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BreakdownResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/compare": {
+            "get": {
+                "description": "Delta total dan per kategori (absolut dan persen) antara periode sekarang (filter period / from / to, default bulan ini) dan periode pembanding. Pembanding default adalah periode sebelumnya dengan panjang sama (bulan kalender digeser per bulan), atau last_year, atau compare_from / compare_to. top_movers berisi kategori dengan perubahan absolut terbesar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Perbandingan dua periode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preset periode sekarang (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "previous (default) atau last_year",
+                        "name": "compare",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai periode pembanding",
+                        "name": "compare_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir periode pembanding, inklusif",
+                        "name": "compare_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/donut": {
+            "get": {
+                "description": "Menampilkan grafik donat pemasukan per kategori (type bisa diganti lewat filter)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Grafik donat pemasukan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preset periode (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter type (pemasukan/pengeluaran)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/monthly-bar": {
+            "get": {
+                "description": "Menampilkan pengeluaran per kategori tiap bulan. Tanpa filter periode, default 3 bulan terakhir.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Statistik pengeluaran per bulan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preset periode (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter type (pemasukan/pengeluaran)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseWithMonths"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/yoy": {
+            "get": {
+                "description": "Pemasukan, pengeluaran dan net satu bulan kalender (default bulan ini) selama beberapa tahun terakhir, beserta perubahan persen dibanding tahun sebelumnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Perbandingan bulan yang sama antar tahun",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bulan 1-12 (default bulan ini)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah tahun (default 5, maksimal 20)",
+                        "name": "years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter payee",
+                        "name": "payee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.YoYResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/debts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Daftar hutang piutang",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hutang atau piutang",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "open, paid atau written_off",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama orang (tidak case sensitive)",
+                        "name": "counterparty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DebtResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "direction hutang = kita meminjam, piutang = kita meminjamkan. transaction_id opsional menghubungkan transaksi pencairan approved yang belum dipakai pembayaran lain (piutang: pengeluaran, hutang: pemasukan).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Catat hutang atau piutang",
+                "parameters": [
+                    {
+                        "description": "Hutang piutang",
+                        "name": "debt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DebtRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.DebtResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/debts/balances": {
+            "get": {
+                "description": "Net positif berarti orang itu masih berhutang ke kita, negatif berarti kita yang berhutang",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Sisa hutang piutang per orang",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CounterpartyBalance"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/debts/overdue": {
+            "get": {
+                "description": "Masih open dan due_date sebelum hari ini, yang paling lama terlambat dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Hutang piutang yang lewat jatuh tempo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hutang atau piutang",
+                        "name": "direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DebtResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/debts/repayments/{id}": {
+            "delete": {
+                "description": "Sisa bertambah lagi dan status lunas dibuka kembali; transaksinya tidak dihapus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Batalkan pembayaran hutang piutang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Repayment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DebtResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/debts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Detail hutang piutang beserta riwayat pembayaran",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Debt ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DebtResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Semua field opsional. due_date kosong menghapus jatuh tempo. status written_off untuk mengikhlaskan / berhenti menagih, open untuk membuka lagi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Ubah hutang piutang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Debt ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Perubahan",
+                        "name": "debt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DebtUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DebtResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Pembayarannya ikut dihapus; transaksi yang terhubung tetap ada sebagai transaksi biasa",
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Hapus hutang piutang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Debt ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/debts/{id}/repayments": {
+            "post": {
+                "description": "Bisa dicicil. transaction_id opsional menghubungkan transaksi pembayaran approved yang belum dipakai pembayaran lain (piutang: pemasukan, hutang: pengeluaran). Otomatis lunas jika sisa habis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Debts"
+                ],
+                "summary": "Catat pembayaran hutang piutang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Debt ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pembayaran",
+                        "name": "repayment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RepaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.DebtResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/events": {
+            "get": {
+                "description": "Mengirim transaction.created, transaction.updated, transaction.deleted, summary.recomputed, campaign.*, bill.* dan approval.requested milik workspace (X-Workspace-ID) atau user (X-User-ID) yang sama, serta anomaly.detected untuk semua. Event resync berarti ada event yang terlewat dan client sebaiknya fetch ulang. Komentar heartbeat dikirim tiap 25 detik.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream event real-time (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Daftar tipe event dipisah koma (default semua)",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "text/event-stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/forecast": {
+            "get": {
+                "description": "Proyeksi saldo mulai besok dari saldo sekarang, arus kas pasti (recurring transaction dan cicilan yang belum dibayar) dan rata-rata musiman pengeluaran variabel per kategori. Band best / worst = expected ± simpangan baku pengeluaran variabel yang terakumulasi (tumbuh sebanding akar jumlah hari). first_negative_date adalah tanggal pertama saldo expected diproyeksikan negatif.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Forecast"
+                ],
+                "summary": "Proyeksi saldo harian",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "30, 60 atau 90 (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/installments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Daftar plan cicilan beserta sisa kewajiban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "active, completed atau cancelled",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "plans dan total_remaining",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Jadwal cicilan bulanan dibuat otomatis: pokok dibagi rata, bunga flat interest_rate persen per bulan dari pokok, monthly_fee tiap bulan dan admin_fee di cicilan pertama. Transaksi pengeluaran approved dengan payee yang sama dan nominal yang cocok otomatis menandai cicilan lunas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Tambah plan cicilan / paylater",
+                "parameters": [
+                    {
+                        "description": "Plan cicilan",
+                        "name": "plan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InstallmentPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.InstallmentPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/installments/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Detail plan cicilan beserta jadwal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InstallmentPlanResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Jadwal ikut dihapus; transaksi pembayaran tetap ada sebagai transaksi biasa",
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Hapus plan cicilan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/installments/{id}/cancel": {
+            "post": {
+                "description": "Untuk pelunasan dipercepat atau pembatalan: sisa cicilan tidak lagi dihitung sebagai kewajiban maupun di forecast",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Tutup plan cicilan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InstallmentPlanResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/installments/{id}/installments/{number}/payment": {
+            "delete": {
+                "description": "Cicilan kembali belum dibayar; transaksinya tidak dihapus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Batalkan pembayaran cicilan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor cicilan",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InstallmentPlanResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/installments/{id}/pay": {
+            "post": {
+                "description": "Untuk pembayaran yang tidak tercocokkan otomatis (payee berbeda, dibayar gabungan, dll). transaction_id harus pengeluaran approved yang belum dipakai tagihan, cicilan atau hutang piutang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Tandai cicilan lunas secara manual",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pembayaran",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PayInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InstallmentPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/net-worth": {
+            "get": {
+                "description": "Posisi akhir bulan: saldo kas (saldo kumulatif transaksi approved, sama dengan GetDashboard) + aset - liabilitas. Nilai item memakai penilaian terakhir pada atau sebelum tanggal tersebut. Bulan berjalan dihitung per hari ini.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Net worth dari waktu ke waktu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah bulan ke belakang termasuk bulan ini (default 12, maksimal 120)",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetWorthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/net-worth/items": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Daftar aset dan liabilitas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NetWorthItem"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Kategori asset: tabungan, emas, properti, investasi, kendaraan, lainnya. Kategori liability: kpr, kredit kendaraan, paylater, kartu kredit, pinjaman, lainnya. Nilai liabilitas dicatat positif (sisa utang).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Tambah aset atau liabilitas",
+                "parameters": [
+                    {
+                        "description": "Item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NetWorthItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetWorthItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/net-worth/items/{id}": {
+            "put": {
+                "description": "Semua field opsional. Isi closed_at saat aset dijual atau utang lunas (string kosong untuk membuka lagi). value / valued_at diabaikan, pakai endpoint valuations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Ubah aset atau liabilitas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Perubahan",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NetWorthItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetWorthItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Semua penilaiannya ikut dihapus. Untuk aset yang dijual / utang lunas, lebih baik isi closed_at supaya histori tetap ada.",
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Hapus aset atau liabilitas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/net-worth/items/{id}/valuations": {
+            "post": {
+                "description": "Satu nilai per tanggal; mencatat ulang tanggal yang sama menimpa nilai sebelumnya. Tanpa date dicatat untuk hari ini.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Catat nilai aset / sisa utang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Penilaian",
+                        "name": "valuation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValuationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetWorthValuation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/net-worth/valuations/{id}": {
+            "delete": {
+                "tags": [
+                    "Net Worth"
+                ],
+                "summary": "Hapus satu penilaian",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Valuation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications": {
+            "get": {
+                "description": "Notifikasi in-app milik user (X-User-ID), terbaru di atas, beserta jumlah yang belum dibaca",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Inbox notifikasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pemilik inbox",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya yang belum dibaca",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimal (default 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationInbox"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/preferences": {
+            "get": {
+                "description": "Jika belum pernah disimpan, routes kosong dan user belum menerima notifikasi apa pun",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Preferensi notifikasi user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreferenceResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Mengganti seluruh preferensi. Event: bill.upcoming, bill.due, bill.overdue, bill.paid, anomaly.detected, approval.requested (hanya untuk manager). Channel: inbox, email (butuh email), webhook (butuh webhook_url). Workspace dan role ikut disimpan dari header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Atur event mana dikirim ke channel mana",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Preferensi",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/read-all": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Tandai semua notifikasi sudah dibaca",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pemilik inbox",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/test": {
+            "post": {
+                "description": "Mengirim pesan uji ke setiap channel yang dipakai preferensi user, misal untuk mengecek SMTP ke mail catcher lokal. Hasil per channel berisi \"ok\" atau pesan error.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Kirim notifikasi uji",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{id}": {
+            "delete": {
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Hapus notifikasi dari inbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pemilik inbox",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{id}/read": {
+            "post": {
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Tandai notifikasi sudah dibaca",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pemilik inbox",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/recurring": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring"
+                ],
+                "summary": "Daftar recurring transaction",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecurringTransaction"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Template transaksi berulang (gaji, sewa, langganan) yang dipakai forecast",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring"
+                ],
+                "summary": "Tambah recurring transaction",
+                "parameters": [
+                    {
+                        "description": "Recurring transaction",
+                        "name": "recurring",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RecurringRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecurringTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/recurring/{id}": {
+            "delete": {
+                "description": "Transaksi yang sudah tercatat tidak berubah dan tetap tidak dihitung sebagai pengeluaran variabel",
+                "tags": [
+                    "Recurring"
+                ],
+                "summary": "Hapus recurring transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions": {
+            "get": {
+                "description": "Menampilkan semua transaksi dengan filter dan pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Ambil daftar transaksi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by type (pemasukan/pengeluaran)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft/submitted/approved/rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by payee",
+                        "name": "payee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset periode (this_month/last_month/last_30_days/ytd/custom)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari di deskripsi",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount minimal",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount maksimal",
+                        "name": "max_amount",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TransactionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Menambahkan data transaksi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Tambah transaksi baru",
+                "parameters": [
+                    {
+                        "description": "Transaksi baru",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/top5": {
+            "get": {
+                "description": "Mendapatkan 5 transaksi terbaru berdasarkan tanggal dibuat (created_at DESC).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get top 5 latest transactions",
+                "responses": {
+                    "200": {
+                        "description": "Daftar 5 transaksi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/models.TransactionResponse"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/{id}": {
+            "delete": {
+                "description": "Menghapus transaksi berdasarkan ID",
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Hapus transaksi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/{id}/approve": {
+            "post": {
+                "description": "Manager menyetujui transaksi yang sedang menunggu approval",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval"
+                ],
+                "summary": "Approve pengeluaran",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Manager",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Harus manager",
+                        "name": "X-User-Role",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Komentar approver",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/{id}/attachments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Daftar lampiran transaksi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mengunggah satu atau beberapa struk (JPEG, PNG, WebP, PDF, maks 5MB per file) ke sebuah transaksi",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Upload lampiran transaksi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File lampiran (boleh lebih dari satu)",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/{id}/history": {
+            "get": {
+                "description": "Semua perubahan status transaksi beserta komentar approver",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval"
+                ],
+                "summary": "Riwayat approval transaksi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ApprovalHistory"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/{id}/reject": {
+            "post": {
+                "description": "Manager menolak transaksi yang sedang menunggu approval (komentar wajib)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval"
+                ],
+                "summary": "Reject pengeluaran",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Manager",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Harus manager",
+                        "name": "X-User-Role",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan penolakan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/{id}/submit": {
+            "post": {
+                "description": "Mengajukan ulang transaksi. Pengeluaran di atas threshold menunggu approval, sisanya langsung approved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval"
+                ],
+                "summary": "Submit transaksi draft / rejected",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User yang mengajukan",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Daftar subscription webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Event dikirim sebagai POST JSON {id, type, user_id, workspace, data, at}. Header X-CashFlow-Signature berisi \"t=\u003cunix\u003e,v1=\u003chex\u003e\" dengan v1 = HMAC-SHA256(secret, \"\u003ct\u003e.\u003cbody\u003e\"). Secret hanya dikembalikan sekali di response ini. Filter events menerima tipe event, \"\u003cgrup\u003e.*\" (misal \"transaction.*\") atau \"*\". Subscription dibatasi ke workspace manager; header X-Workspace-ID wajib.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Tambah subscription webhook",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookCreated"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/dead-letters": {
+            "get": {
+                "description": "Pengiriman yang gagal sampai batas retry (8 percobaan dengan exponential backoff) atau webhook-nya dinonaktifkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Dead-letter webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimal (default 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries/{id}": {
+            "get": {
+                "description": "Termasuk payload dan log setiap percobaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Detail pengiriman webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "description": "Payload yang sama dikirim ulang (header X-CashFlow-Delivery tetap) dengan jatah retry baru",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Kirim ulang pengiriman webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Detail subscription webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Semua field opsional. Pengiriman yang masih antre untuk webhook nonaktif langsung masuk dead-letter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Ubah URL, filter event atau status aktif webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Perubahan",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Log pengiriman webhook ini ikut dihapus",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Hapus subscription webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Log pengiriman satu webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, succeeded atau dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimal (default 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.ApprovalRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Oke, sesuai budget"
+                }
+            }
+        },
+        "handlers.BillPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 432000
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-09-18"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2025-09"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 91
+                }
+            }
+        },
+        "handlers.BillRequest": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 450000
+                },
+                "amount_type": {
+                    "type": "string",
+                    "example": "estimated"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "listrik"
+                },
+                "due_day": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Listrik PLN"
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "PLN"
+                },
+                "reminder_days": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.DebtRequest": {
+            "type": "object",
+            "properties": {
+                "counterparty": {
+                    "type": "string",
+                    "example": "Andi"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Pinjam buat DP motor"
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "piutang"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-10-01"
+                },
+                "principal": {
+                    "type": "number",
+                    "example": 2000000
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-08-01"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.DebtUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "counterparty": {
+                    "type": "string",
+                    "example": "Andi"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Pinjam buat DP motor"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-11-01"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "written_off"
+                }
+            }
+        },
+        "handlers.InstallmentPlanRequest": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
+                "admin_fee": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "category": {
+                    "type": "string",
+                    "example": "elektronik"
+                },
+                "first_due_date": {
+                    "type": "string",
+                    "example": "2025-09-05"
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "example": 0
+                },
+                "monthly_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15"
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "Kredivo"
+                },
+                "principal": {
+                    "type": "number",
+                    "example": 12000000
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "Kredivo"
+                },
+                "tenor": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handlers.NetWorthItemRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "emas"
+                },
+                "closed_at": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "asset"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emas Antam 10 gram"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Disimpan di brankas"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 12500000
+                },
+                "valued_at": {
+                    "type": "string",
+                    "example": "2025-08-31"
+                }
+            }
+        },
+        "handlers.NotificationPreferenceRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "budi@example.com"
+                },
+                "routes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/budi"
+                }
+            }
+        },
+        "handlers.PayInstallmentRequest": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-11-04"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 88
+                }
+            }
+        },
+        "handlers.RecurringRequest": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "category": {
+                    "type": "string",
+                    "example": "tempat tinggal"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Sewa kos"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-07-01"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "Bu Kos"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-08-01"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "pengeluaran"
+                }
+            }
+        },
+        "handlers.RepaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-25"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Transfer BCA"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 57
+                }
+            }
+        },
+        "handlers.ValuationRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-31"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Harga buyback"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 12500000
+                }
+            }
+        },
+        "handlers.WebhookCreated": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Bot Discord keluarga"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"transaction.*\"]"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3f9c..."
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/cashflow"
+                },
+                "workspace": {
+                    "type": "string",
+                    "example": "keluarga-budi"
+                }
+            }
+        },
+        "handlers.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Bot Discord keluarga"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "transaction.*",
+                        "campaign.created"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/cashflow"
+                }
+            }
+        },
+        "models.Anomaly": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 3600000
+                },
+                "category": {
+                    "type": "string",
+                    "example": "makanan"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dismissed": {
+                    "type": "boolean"
+                },
+                "expected": {
+                    "type": "number",
+                    "example": 1200000
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Pengeluaran makanan bulan ini sudah Rp3.600.000, 3.0x rata-rata bulanan (median Rp1.200.000 dari 6 bulan)"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "category_spike"
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2025-09"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 3
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.ApprovalHistory": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "manager-1"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Oke, sesuai budget"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "submitted"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "approved"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-07T12:00:00Z"
+                },
+                "download_url": {
+                    "type": "string",
+                    "example": "/api/attachments/1/download"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "struk-gacoan.jpg"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 204800
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "uploaded_by": {
+                    "type": "string",
+                    "example": "budi"
+                }
+            }
+        },
+        "models.Bill": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 450000
+                },
+                "amount_type": {
+                    "type": "string",
+                    "example": "estimated"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "listrik"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "due_day": {
+                    "description": "DueDay tanggal jatuh tempo tiap bulan; 29-31 jatuh di akhir bulan pada bulan yang lebih pendek",
+                    "type": "integer",
+                    "example": 20
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Listrik PLN"
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "PLN"
+                },
+                "reminder_days": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace": {
+                    "type": "string",
+                    "example": "keluarga-budi"
+                }
+            }
+        },
+        "models.BillPayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 432000
+                },
+                "bill_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2025-08"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 91
+                }
+            }
+        },
+        "models.BreakdownItem": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "key": {
+                    "type": "string",
+                    "example": "makanan"
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 35.5
+                },
+                "total": {
+                    "type": "number",
+                    "example": 250000
+                }
+            }
+        },
+        "models.BreakdownResponse": {
+            "type": "object",
+            "properties": {
+                "by": {
+                    "type": "string",
+                    "example": "category"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BreakdownItem"
+                    }
+                },
+                "total": {
+                    "type": "number",
+                    "example": 704000
+                },
+                "type": {
+                    "type": "string",
+                    "example": "pengeluaran"
+                }
+            }
+        },
+        "models.Campaign": {
+            "type": "object",
+            "properties": {
+                "click_url": {
+                    "type": "string",
+                    "example": "https://example.com/promo"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "2025-08-14T08:00:00+07:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_height": {
+                    "type": "integer",
+                    "example": 640
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "http://localhost:8889/files/campaigns/1700000000_banner.jpg?expires=1700003600\u0026signature=abc"
+                },
+                "image_width": {
+                    "description": "Gambar sudah diproses: EXIF dibuang dan varian responsif dibuat saat upload",
+                    "type": "integer",
+                    "example": 1920
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "placement": {
+                    "description": "Placement dan rotasi: di antara campaign yang overlap, prioritas tertinggi menang,\nlalu dipilih acak berbobot berdasarkan Weight.",
+                    "type": "string",
+                    "example": "dashboard-top"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "recurrence_days": {
+                    "description": "Jadwal berulang opsional di dalam StartAt - EndAt, misal tiap Jumat 17:00-21:00.\nJam dihitung dalam Timezone campaign; End \u003c= Start berarti melewati tengah malam.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"fri\"]"
+                    ]
+                },
+                "recurrence_end": {
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "recurrence_start": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "start_at": {
+                    "description": "StartAt / EndAt disimpan dalam UTC dan ditampilkan dalam Timezone campaign",
+                    "type": "string",
+                    "example": "2025-08-07T08:00:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "live"
+                },
+                "target_roles": {
+                    "description": "Targeting opsional, kosong berarti tampil untuk semua",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"manager\"]"
+                    ]
+                },
+                "target_workspaces": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"keluarga-budi\"]"
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "thumbnail": "http://localhost:8889/files/campaigns/1700000000_banner_thumbnail.jpg"
+                    }
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CampaignDayStat": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "ctr": {
+                    "type": "number",
+                    "example": 5
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-07"
+                },
+                "impressions": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "models.CampaignStatsResponse": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "clicks": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "ctr": {
+                    "type": "number",
+                    "example": 5
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CampaignDayStat"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-08-07"
+                },
+                "impressions": {
+                    "type": "integer",
+                    "example": 840
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-08-14"
+                }
+            }
+        },
+        "models.CategoryDelta": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "makanan"
+                },
+                "change": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "change_percent": {
+                    "type": "number",
+                    "example": 16.67
+                },
+                "current": {
+                    "type": "number",
+                    "example": 3500000
+                },
+                "previous": {
+                    "type": "number",
+                    "example": 3000000
+                },
+                "type": {
+                    "type": "string",
+                    "example": "pengeluaran"
+                }
+            }
+        },
+        "models.ComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryDelta"
+                    }
+                },
+                "current": {
+                    "$ref": "#/definitions/models.PeriodRange"
+                },
+                "expense": {
+                    "$ref": "#/definitions/models.Delta"
+                },
+                "income": {
+                    "$ref": "#/definitions/models.Delta"
+                },
+                "net": {
+                    "$ref": "#/definitions/models.Delta"
+                },
+                "previous": {
+                    "$ref": "#/definitions/models.PeriodRange"
+                },
+                "top_movers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryDelta"
+                    }
+                }
+            }
+        },
+        "models.CounterpartyBalance": {
+            "type": "object",
+            "properties": {
+                "counterparty": {
+                    "type": "string",
+                    "example": "Andi"
+                },
+                "net": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "open_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "overdue_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "payable": {
+                    "type": "number",
+                    "example": 0
+                },
+                "receivable": {
+                    "type": "number",
+                    "example": 1500000
+                }
+            }
+        },
+        "models.DebtRepayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-25T00:00:00Z"
+                },
+                "debt_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Transfer BCA"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 57
+                }
+            }
+        },
+        "models.DebtResponse": {
+            "type": "object",
+            "properties": {
+                "counterparty": {
+                    "type": "string",
+                    "example": "Andi"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "days_overdue": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Pinjam buat DP motor"
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "piutang"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-10-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "outstanding": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "principal": {
+                    "type": "number",
+                    "example": 2000000
+                },
+                "repaid": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "repayments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DebtRepayment"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-08-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Delta": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "change_percent": {
+                    "type": "number",
+                    "example": 16.67
+                },
+                "current": {
+                    "type": "number",
+                    "example": 3500000
+                },
+                "previous": {
+                    "type": "number",
+                    "example": 3000000
+                }
+            }
+        },
+        "models.ForecastCategory": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "makanan"
+                },
+                "monthly": {
+                    "type": "number",
+                    "example": 2100000
+                },
+                "std_dev": {
+                    "type": "number",
+                    "example": 300000
+                }
+            }
+        },
+        "models.ForecastDay": {
+            "type": "object",
+            "properties": {
+                "best": {
+                    "type": "number",
+                    "example": 4350000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "expected": {
+                    "type": "number",
+                    "example": 4200000
+                },
+                "known": {
+                    "type": "number",
+                    "example": -1500000
+                },
+                "variable": {
+                    "type": "number",
+                    "example": 85000
+                },
+                "worst": {
+                    "type": "number",
+                    "example": 4050000
+                }
+            }
+        },
+        "models.ForecastEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -1500000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Sewa kos"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "recurring"
+                },
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ForecastResponse": {
+            "type": "object",
+            "properties": {
+                "current_balance": {
+                    "type": "number",
+                    "example": 5000000
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ForecastDay"
+                    }
+                },
+                "days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ForecastEvent"
+                    }
+                },
+                "first_negative_date": {
+                    "type": "string",
+                    "example": "2025-09-20"
+                },
+                "first_negative_date_worst": {
+                    "type": "string",
+                    "example": "2025-09-14"
+                },
+                "variable": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ForecastCategory"
+                    }
+                }
+            }
+        },
+        "models.Installment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1050000
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-09-05T00:00:00Z"
+                },
+                "fee": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "interest": {
+                    "type": "number",
+                    "example": 0
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "plan_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "principal": {
+                    "type": "number",
+                    "example": 1000000
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 88
+                }
+            }
+        },
+        "models.InstallmentPlanResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
+                "admin_fee": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "category": {
+                    "type": "string",
+                    "example": "elektronik"
+                },
+                "cost_rate_per_year": {
+                    "type": "number",
+                    "example": 0.42
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "first_due_date": {
+                    "type": "string",
+                    "example": "2025-09-05T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Installment"
+                    }
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "example": 0
+                },
+                "monthly_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15"
+                },
+                "next_amount": {
+                    "type": "number",
+                    "example": 1000000
+                },
+                "next_due_date": {
+                    "type": "string",
+                    "example": "2025-11-05T00:00:00Z"
+                },
+                "overdue_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "paid": {
+                    "type": "number",
+                    "example": 2050000
+                },
+                "paid_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "Kredivo"
+                },
+                "principal": {
+                    "type": "number",
+                    "example": 12000000
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "Kredivo"
+                },
+                "remaining": {
+                    "type": "number",
+                    "example": 10000000
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "tenor": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "total_cost": {
+                    "type": "number",
+                    "example": 12050000
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MonthlyCategoryGroup": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MonthlyCategoryItem"
+                    }
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MonthlyCategoryItem": {
+            "type": "object",
+            "properties": {
+                "category2": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.NetWorthItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "emas"
+                },
+                "closed_at": {
+                    "description": "ClosedAt diisi saat aset dijual / utang lunas; setelah tanggal ini item tidak dihitung",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "asset"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emas Antam 10 gram"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Disimpan di brankas"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valuations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetWorthValuation"
+                    }
+                }
+            }
+        },
+        "models.NetWorthItemValue": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "emas"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "asset"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emas Antam 10 gram"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 12500000
+                },
+                "valued_at": {
+                    "type": "string",
+                    "example": "2025-08-31"
+                }
+            }
+        },
+        "models.NetWorthPoint": {
+            "type": "object",
+            "properties": {
+                "assets": {
+                    "type": "number",
+                    "example": 350000000
+                },
+                "cash": {
+                    "type": "number",
+                    "example": 8500000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-31"
+                },
+                "liabilities": {
+                    "type": "number",
+                    "example": 210000000
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2025-08"
+                },
+                "net_worth": {
+                    "type": "number",
+                    "example": 148500000
+                }
+            }
+        },
+        "models.NetWorthResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/models.NetWorthPoint"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetWorthItemValue"
+                    }
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetWorthPoint"
+                    }
+                }
+            }
+        },
+        "models.NetWorthValuation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-31T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "item_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Harga buyback"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 12500000
+                }
+            }
+        },
+        "models.Notification": {
             "type": "object",
             "properties": {
-                "end_at": {
+                "body": {
+                    "type": "string",
+                    "example": "Perkiraan Rp450.000, jatuh tempo 2025-09-20."
+                },
+                "created_at": {
                     "type": "string"
                 },
+                "event_type": {
+                    "type": "string",
+                    "example": "bill.due"
+                },
                 "id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 1
                 },
-                "image_url": {
+                "read_at": {
                     "type": "string"
                 },
-                "start_at": {
-                    "type": "string"
+                "title": {
+                    "type": "string",
+                    "example": "Tagihan Listrik PLN jatuh tempo hari ini"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "budi"
                 }
             }
         },
-        "models.MonthlyCategoryGroup": {
+        "models.NotificationInbox": {
             "type": "object",
             "properties": {
-                "categories": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.MonthlyCategoryItem"
+                        "$ref": "#/definitions/models.Notification"
                     }
                 },
-                "month": {
+                "unread": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.NotificationPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "budi@example.com"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "manager"
+                },
+                "routes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/budi"
+                },
+                "workspace": {
+                    "type": "string",
+                    "example": "keluarga-budi"
                 }
             }
         },
-        "models.MonthlyCategoryItem": {
+        "models.PeriodRange": {
             "type": "object",
             "properties": {
-                "category2": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-09-30"
+                }
+            }
+        },
+        "models.RecurringTransaction": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "category": {
+                    "type": "string",
+                    "example": "tempat tinggal"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "number"
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Sewa kos"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "Berulang tiap Interval x Frequency sejak StartDate, sampai EndDate (inklusif) jika ada.\nUntuk monthly, tanggal 29-31 jatuh di akhir bulan pada bulan yang lebih pendek.",
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "Bu Kos"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-08-01T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "pengeluaran"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
@@ -409,10 +5828,22 @@ const docTemplate = `{
         "models.Transaction": {
             "type": "object",
             "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "bca"
+                },
                 "amount": {
                     "type": "number",
                     "example": 15000
                 },
+                "approval_comment": {
+                    "type": "string",
+                    "example": "Oke, sesuai budget"
+                },
+                "approved_by": {
+                    "type": "string",
+                    "example": "manager-1"
+                },
                 "categories": {
                     "type": "array",
                     "items": {
@@ -438,6 +5869,19 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2025-08-07T12:00:00Z"
                 },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "debt_id": {
+                    "description": "Diisi jika transaksi adalah pencairan atau pembayaran hutang piutang (diatur lewat /api/debts)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "decided_at": {
+                    "type": "string",
+                    "example": "2025-08-08T09:00:00Z"
+                },
                 "description": {
                     "type": "string",
                     "example": "Beli Mie Gacoan"
@@ -446,6 +5890,25 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "installment_id": {
+                    "description": "Diisi jika transaksi adalah pembayaran cicilan (dicocokkan otomatis atau lewat /api/installments)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "payee": {
+                    "type": "string",
+                    "example": "Mie Gacoan"
+                },
+                "recurring_id": {
+                    "description": "Diisi jika transaksi adalah realisasi recurring transaction",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "Approval workflow. Transaksi lama otomatis dianggap approved.",
+                    "type": "string",
+                    "example": "approved"
+                },
                 "transaction_at": {
                     "type": "string",
                     "example": "2025-08-07T12:00:00Z"
@@ -459,6 +5922,9 @@ const docTemplate = `{
         "models.TransactionResponse": {
             "type": "object",
             "properties": {
+                "account": {
+                    "type": "string"
+                },
                 "amount": {
                     "type": "number"
                 },
@@ -466,15 +5932,30 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "created_at": {
-                    "description": "string untuk tampil WIB",
+                    "description": "string dalam zona aplikasi",
+                    "type": "string"
+                },
+                "created_by": {
                     "type": "string"
                 },
+                "debt_id": {
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "installment_id": {
+                    "type": "integer"
+                },
+                "payee": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "transaction_at": {
                     "type": "string"
                 },
@@ -482,6 +5963,217 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.UpcomingBill": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 450000
+                },
+                "bill_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "type": "string",
+                    "example": "listrik"
+                },
+                "days_until": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-09-20"
+                },
+                "estimated": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Listrik PLN"
+                },
+                "payment": {
+                    "$ref": "#/definitions/models.BillPayment"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2025-09"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "unpaid"
+                }
+            }
+        },
+        "models.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "transaction.created"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "HTTP 502"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 502
+                },
+                "log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookAttempt"
+                    }
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "budi"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Bot Discord keluarga"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"transaction.*\"]"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/cashflow"
+                },
+                "workspace": {
+                    "type": "string",
+                    "example": "keluarga-budi"
+                }
+            }
+        },
+        "models.YoYResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string",
+                    "example": "September"
+                },
+                "years": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.YoYYear"
+                    }
+                }
+            }
+        },
+        "models.YoYYear": {
+            "type": "object",
+            "properties": {
+                "expense": {
+                    "type": "number",
+                    "example": 7500000
+                },
+                "expense_change_percent": {
+                    "type": "number",
+                    "example": -3.1
+                },
+                "income": {
+                    "type": "number",
+                    "example": 10000000
+                },
+                "income_change_percent": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "net": {
+                    "type": "number",
+                    "example": 2500000
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,133 @@
+// Package events adalah event bus in-process. Handler mem-publish perubahan data
+// (transaksi dibuat / dihapus, ringkasan dihitung ulang) dan subscriber seperti
+// endpoint SSE menerimanya tanpa saling kenal.
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Tipe event
+const (
+	TransactionCreated = "transaction.created"
+	TransactionUpdated = "transaction.updated"
+	TransactionDeleted = "transaction.deleted"
+	SummaryRecomputed  = "summary.recomputed"
+
+	CampaignCreated     = "campaign.created"
+	CampaignUpdated     = "campaign.updated"
+	CampaignDeleted     = "campaign.deleted"
+	CampaignActivated   = "campaign.activated"
+	CampaignDeactivated = "campaign.deactivated"
+
+	BillUpcoming = "bill.upcoming"
+	BillDue      = "bill.due"
+	BillOverdue  = "bill.overdue"
+	BillPaid     = "bill.paid"
+
+	AnomalyDetected   = "anomaly.detected"
+	ApprovalRequested = "approval.requested"
+)
+
+// Types adalah semua tipe event yang dikenal, dipakai untuk validasi filter subscriber
+var Types = []string{
+	TransactionCreated, TransactionUpdated, TransactionDeleted, SummaryRecomputed,
+	CampaignCreated, CampaignUpdated, CampaignDeleted, CampaignActivated, CampaignDeactivated,
+	BillUpcoming, BillDue, BillOverdue, BillPaid,
+	AnomalyDetected, ApprovalRequested,
+}
+
+// Event adalah satu kejadian. UserID dan Workspace adalah pemilik / pelaku,
+// dipakai subscriber untuk membatasi siapa yang boleh menerima.
+type Event struct {
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"`
+	UserID    string      `json:"user_id,omitempty"`
+	Workspace string      `json:"workspace,omitempty"`
+	Data      interface{} `json:"data"`
+	At        time.Time   `json:"at"`
+}
+
+// Global berarti event tanpa pemilik, tentang data bersama yang bisa dilihat semua user
+// (misal anomaly.detected). SSE, webhook dan notifikasi mengirimnya ke semua penerima.
+func (e Event) Global() bool {
+	return e.UserID == "" && e.Workspace == ""
+}
+
+// Subscription menerima event yang lolos filter lewat C.
+// Jika subscriber terlalu lambat dan buffer penuh, event dibuang dan Lagged jadi true
+// supaya subscriber tahu harus sinkron ulang.
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	filter func(Event) bool
+	lagged atomic.Bool
+}
+
+// Lagged mengembalikan true (sekali) jika ada event yang terbuang sejak pemanggilan terakhir
+func (s *Subscription) Lagged() bool {
+	return s.lagged.Swap(false)
+}
+
+// Bus menyalurkan event ke semua subscriber
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[*Subscription]struct{}
+	nextID atomic.Uint64
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: map[*Subscription]struct{}{}}
+}
+
+// Default adalah bus aplikasi
+var Default = NewBus()
+
+// Subscribe mendaftarkan subscriber. filter nil berarti semua event.
+func (b *Bus) Subscribe(filter func(Event) bool, buffer int) *Subscription {
+	ch := make(chan Event, buffer)
+	s := &Subscription{C: ch, ch: ch, filter: filter}
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+// Unsubscribe melepas subscriber dan menutup channel-nya
+func (b *Bus) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.ch)
+	}
+	b.mu.Unlock()
+}
+
+// Publish mengirim event ke semua subscriber yang cocok tanpa pernah memblokir publisher
+func (b *Bus) Publish(e Event) Event {
+	e.ID = b.nextID.Add(1)
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		if s.filter != nil && !s.filter(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			s.lagged.Store(true)
+		}
+	}
+	return e
+}
+
+// Publish mengirim event lewat Default
+func Publish(e Event) Event {
+	return Default.Publish(e)
+}
@@ -0,0 +1,103 @@
+// Package forecast memproyeksikan saldo harian ke depan dari saldo sekarang,
+// transaksi yang sudah pasti (recurring, tagihan, cicilan) dan rata-rata
+// musiman pengeluaran variabel per kategori.
+//
+// Semua tanggal memakai "tanggal lokal" yaitu jam 00:00 UTC yang mewakili
+// tanggal di zona aplikasi, sama seperti dayOf di handlers.
+package forecast
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Event adalah arus kas yang sudah diketahui pada tanggal tertentu.
+// Amount positif untuk pemasukan, negatif untuk pengeluaran.
+type Event struct {
+	Date        time.Time
+	Amount      float64
+	Source      string
+	SourceID    uint
+	Description string
+}
+
+// Day adalah proyeksi satu hari
+type Day struct {
+	Date     time.Time
+	Known    float64 // total event hari itu (bertanda)
+	Variable float64 // perkiraan pengeluaran variabel hari itu
+	Expected float64
+	Best     float64
+	Worst    float64
+}
+
+// Result adalah hasil Project
+type Result struct {
+	Days []Day
+
+	// Tanggal pertama saldo diproyeksikan negatif, nil jika tidak pernah
+	FirstNegative      *time.Time
+	FirstNegativeWorst *time.Time
+}
+
+// Project menghitung saldo harian mulai from (inklusif) selama days hari.
+// Band best / worst adalah expected ± simpangan baku pengeluaran variabel yang terakumulasi.
+// Pengeluaran harian dan antar kategori dianggap independen, jadi yang dijumlahkan adalah
+// variansinya: band tumbuh sebanding akar jumlah hari dan setelah sebulan penuh sama dengan
+// simpangan baku bulanan.
+func Project(balance float64, from time.Time, days int, events []Event, profiles []Profile) Result {
+	known := map[time.Time]float64{}
+	for _, e := range events {
+		known[e.Date] += e.Amount
+	}
+
+	res := Result{Days: make([]Day, 0, days)}
+	expected, variance := balance, 0.0
+	for i := 0; i < days; i++ {
+		date := from.AddDate(0, 0, i)
+
+		var variable float64
+		for _, p := range profiles {
+			variable += p.DailyRate(date)
+			variance += p.DailySpread(date) * p.DailySpread(date)
+		}
+
+		expected += known[date] - variable
+		spread := math.Sqrt(variance)
+
+		day := Day{
+			Date:     date,
+			Known:    known[date],
+			Variable: variable,
+			Expected: expected,
+			Best:     expected + spread,
+			Worst:    expected - spread,
+		}
+		res.Days = append(res.Days, day)
+
+		if res.FirstNegative == nil && day.Expected < 0 {
+			d := date
+			res.FirstNegative = &d
+		}
+		if res.FirstNegativeWorst == nil && day.Worst < 0 {
+			d := date
+			res.FirstNegativeWorst = &d
+		}
+	}
+	return res
+}
+
+// SortEvents mengurutkan event berdasarkan tanggal lalu sumber
+func SortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].Source < events[j].Source
+	})
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,117 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestProjectKnownEvents(t *testing.T) {
+	events := []Event{
+		{Date: date(2025, 9, 2), Amount: -1500, Source: "recurring"},
+		{Date: date(2025, 9, 3), Amount: 2000, Source: "recurring"},
+		{Date: date(2025, 9, 3), Amount: -200, Source: "installment"},
+		{Date: date(2025, 10, 1), Amount: -99999, Source: "recurring"}, // di luar rentang
+	}
+	res := Project(1000, date(2025, 9, 1), 3, events, nil)
+
+	want := []struct{ known, expected float64 }{{0, 1000}, {-1500, -500}, {1800, 1300}}
+	if len(res.Days) != len(want) {
+		t.Fatalf("len(Days) = %d, want %d", len(res.Days), len(want))
+	}
+	for i, w := range want {
+		d := res.Days[i]
+		if !d.Date.Equal(date(2025, 9, 1+i)) || d.Known != w.known || d.Expected != w.expected {
+			t.Errorf("day %d = %+v, want known %v expected %v", i, d, w.known, w.expected)
+		}
+		if d.Best != d.Expected || d.Worst != d.Expected {
+			t.Errorf("day %d band = %v..%v without variable spending", i, d.Worst, d.Best)
+		}
+	}
+	if res.FirstNegative == nil || !res.FirstNegative.Equal(date(2025, 9, 2)) {
+		t.Errorf("FirstNegative = %v, want 2025-09-02", res.FirstNegative)
+	}
+	if res.FirstNegativeWorst == nil || !res.FirstNegativeWorst.Equal(date(2025, 9, 2)) {
+		t.Errorf("FirstNegativeWorst = %v, want 2025-09-02", res.FirstNegativeWorst)
+	}
+}
+
+func TestProjectBand(t *testing.T) {
+	profiles := []Profile{
+		{Category: "makanan", Baseline: 3000, StdDev: 300, Seasonal: [12]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	}
+	res := Project(10000, date(2025, 9, 1), 61, nil, profiles)
+
+	tests := []struct {
+		name     string
+		day      int
+		expected float64
+		spread   float64
+	}{
+		{"first day", 0, 10000 - 100, 300 / math.Sqrt(30)},
+		{"one full month", 29, 7000, 300},
+		{"two full months", 60, 4000, math.Sqrt(2) * 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := res.Days[tt.day]
+			if !near(d.Expected, tt.expected) {
+				t.Errorf("Expected = %v, want %v", d.Expected, tt.expected)
+			}
+			if !near(d.Best-d.Expected, tt.spread) || !near(d.Expected-d.Worst, tt.spread) {
+				t.Errorf("band = %v..%v around %v, want ±%v", d.Worst, d.Best, d.Expected, tt.spread)
+			}
+		})
+	}
+	if res.FirstNegative != nil {
+		t.Errorf("FirstNegative = %v, want nil", res.FirstNegative)
+	}
+}
+
+func TestProjectIndependentCategories(t *testing.T) {
+	flat := [12]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	profiles := []Profile{
+		{Category: "makanan", StdDev: 300, Seasonal: flat},
+		{Category: "transport", StdDev: 400, Seasonal: flat},
+	}
+	res := Project(0, date(2025, 9, 1), 30, nil, profiles)
+	if last := res.Days[29]; !near(last.Best, 500) {
+		t.Errorf("spread after a month = %v, want 500", last.Best)
+	}
+}
+
+func TestProjectWorstGoesNegativeFirst(t *testing.T) {
+	profiles := []Profile{
+		{Category: "makanan", Baseline: 3000, StdDev: 3000, Seasonal: [12]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	}
+	res := Project(2000, date(2025, 9, 1), 30, nil, profiles)
+	if res.FirstNegativeWorst == nil {
+		t.Fatal("FirstNegativeWorst = nil")
+	}
+	if res.FirstNegative == nil || !res.FirstNegativeWorst.Before(*res.FirstNegative) {
+		t.Errorf("FirstNegativeWorst %v should be before FirstNegative %v", res.FirstNegativeWorst, res.FirstNegative)
+	}
+	if !res.FirstNegative.Equal(date(2025, 9, 21)) {
+		t.Errorf("FirstNegative = %v, want 2025-09-21", res.FirstNegative)
+	}
+}
+
+func TestSortEvents(t *testing.T) {
+	events := []Event{
+		{Date: date(2025, 9, 3), Source: "recurring", SourceID: 1},
+		{Date: date(2025, 9, 1), Source: "recurring", SourceID: 2},
+		{Date: date(2025, 9, 3), Source: "installment", SourceID: 3},
+		{Date: date(2025, 9, 3), Source: "installment", SourceID: 4},
+	}
+	SortEvents(events)
+	want := []uint{2, 3, 4, 1}
+	for i, id := range want {
+		if events[i].SourceID != id {
+			t.Errorf("events[%d] = %d, want %d", i, events[i].SourceID, id)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package forecast
+
+import (
+	"math"
+	"time"
+)
+
+// Jumlah bulan histori untuk rata-rata musiman dan bulan terakhir untuk baseline
+const (
+	HistoryMonths  = 12
+	BaselineMonths = 3
+)
+
+// Batas indeks musiman supaya satu bulan aneh tidak membuat proyeksi liar
+const (
+	minSeasonal = 0.5
+	maxSeasonal = 2.0
+)
+
+// MonthTotal adalah total pengeluaran satu kategori dalam satu bulan
+type MonthTotal struct {
+	Month time.Time // tanggal 1 bulan tersebut
+	Total float64
+}
+
+// Profile adalah pola pengeluaran variabel satu kategori
+type Profile struct {
+	Category string
+	Baseline float64     // rata-rata bulanan beberapa bulan terakhir
+	Seasonal [12]float64 // indeks per bulan kalender, 1 = normal
+	StdDev   float64     // simpangan baku total bulanan
+}
+
+// BuildProfile menyusun profil dari total bulanan sebelum bulan asOf.
+// Bulan tanpa transaksi dihitung 0, tapi hanya sejak data pertama kategori itu
+// supaya kategori baru tidak diremehkan.
+func BuildProfile(category string, history []MonthTotal, asOf time.Time) Profile {
+	p := Profile{Category: category}
+	for i := range p.Seasonal {
+		p.Seasonal[i] = 1
+	}
+
+	current := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	windowStart := current.AddDate(0, -HistoryMonths, 0)
+
+	byMonth := map[time.Time]float64{}
+	first := current
+	for _, h := range history {
+		m := time.Date(h.Month.Year(), h.Month.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if m.Before(windowStart) || !m.Before(current) {
+			continue
+		}
+		byMonth[m] += h.Total
+		if m.Before(first) {
+			first = m
+		}
+	}
+	if len(byMonth) == 0 {
+		return p
+	}
+
+	var totals []float64
+	for m := first; m.Before(current); m = m.AddDate(0, 1, 0) {
+		totals = append(totals, byMonth[m])
+	}
+
+	recent := totals[max(len(totals)-BaselineMonths, 0):]
+	p.Baseline = mean(recent)
+
+	avg := mean(totals)
+	var variance float64
+	for _, t := range totals {
+		variance += (t - avg) * (t - avg)
+	}
+	p.StdDev = math.Sqrt(variance / float64(len(totals)))
+
+	// Indeks musiman hanya jika histori satu tahun penuh
+	if len(totals) == HistoryMonths && avg > 0 {
+		for m, total := range byMonth {
+			idx := total / avg
+			p.Seasonal[m.Month()-1] = math.Min(math.Max(idx, minSeasonal), maxSeasonal)
+		}
+		for m := first; m.Before(current); m = m.AddDate(0, 1, 0) {
+			if _, ok := byMonth[m]; !ok {
+				p.Seasonal[m.Month()-1] = minSeasonal
+			}
+		}
+	}
+	return p
+}
+
+// DailyRate adalah perkiraan pengeluaran kategori pada tanggal tersebut
+func (p Profile) DailyRate(date time.Time) float64 {
+	return p.Baseline * p.Seasonal[date.Month()-1] / float64(daysIn(date))
+}
+
+// DailySpread adalah simpangan baku harian, dengan anggapan pengeluaran tiap hari dalam
+// sebulan independen sehingga variansi bulanan terbagi rata per hari
+func (p Profile) DailySpread(date time.Time) float64 {
+	return p.StdDev / math.Sqrt(float64(daysIn(date)))
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,118 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func month(y int, m time.Month) time.Time {
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestBuildProfile(t *testing.T) {
+	asOf := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)
+
+	// Setahun penuh Sep 2024 - Aug 2025: 100 per bulan, Desember 400, Maret kosong.
+	// Bulan berjalan dan bulan sebelum jendela tidak ikut dihitung.
+	var year []MonthTotal
+	for m := month(2024, 9); m.Before(month(2025, 9)); m = m.AddDate(0, 1, 0) {
+		switch m.Month() {
+		case time.December:
+			year = append(year, MonthTotal{m, 300}, MonthTotal{m, 100})
+		case time.March:
+		default:
+			year = append(year, MonthTotal{m, 100})
+		}
+	}
+	year = append(year, MonthTotal{month(2025, 9), 9999}, MonthTotal{month(2024, 8), 9999})
+	normal := 100 / (1400.0 / 12)
+
+	flat := [12]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	seasonal := [12]float64{}
+	for i := range seasonal {
+		seasonal[i] = normal
+	}
+	seasonal[time.December-1] = maxSeasonal
+	seasonal[time.March-1] = minSeasonal
+
+	tests := []struct {
+		name     string
+		history  []MonthTotal
+		baseline float64
+		stdDev   float64
+		seasonal [12]float64
+	}{
+		{"no history", nil, 0, 0, flat},
+		{"new category", []MonthTotal{{month(2025, 7), 300}, {month(2025, 8), 600}}, 450, 150, flat},
+		{"gap counts as zero since first month", []MonthTotal{{month(2025, 6), 300}, {month(2025, 8), 600}},
+			300, math.Sqrt((0 + 300*300 + 300*300) / 3.0), flat},
+		{"only current month", []MonthTotal{{month(2025, 9), 500}}, 0, 0, flat},
+		{"full year with clamped seasonal index", year, 100, stdDevOf(year, asOf), seasonal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := BuildProfile("makanan", tt.history, asOf)
+			if p.Category != "makanan" {
+				t.Errorf("Category = %q", p.Category)
+			}
+			if !near(p.Baseline, tt.baseline) || !near(p.StdDev, tt.stdDev) {
+				t.Errorf("baseline/stddev = %v/%v, want %v/%v", p.Baseline, p.StdDev, tt.baseline, tt.stdDev)
+			}
+			for i := range p.Seasonal {
+				if !near(p.Seasonal[i], tt.seasonal[i]) {
+					t.Errorf("Seasonal[%s] = %v, want %v", time.Month(i+1), p.Seasonal[i], tt.seasonal[i])
+				}
+			}
+		})
+	}
+}
+
+// stdDevOf menghitung simpangan baku populasi total bulanan dalam jendela 12 bulan
+func stdDevOf(history []MonthTotal, asOf time.Time) float64 {
+	current := month(asOf.Year(), asOf.Month())
+	totals := map[time.Time]float64{}
+	for m := current.AddDate(0, -HistoryMonths, 0); m.Before(current); m = m.AddDate(0, 1, 0) {
+		totals[m] = 0
+	}
+	for _, h := range history {
+		if _, ok := totals[h.Month]; ok {
+			totals[h.Month] += h.Total
+		}
+	}
+	var sum, sq float64
+	for _, v := range totals {
+		sum += v
+	}
+	avg := sum / float64(len(totals))
+	for _, v := range totals {
+		sq += (v - avg) * (v - avg)
+	}
+	return math.Sqrt(sq / float64(len(totals)))
+}
+
+func TestProfileDailyRates(t *testing.T) {
+	p := Profile{Baseline: 3000, StdDev: 300, Seasonal: [12]float64{1, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}}
+	tests := []struct {
+		date   time.Time
+		rate   float64
+		spread float64
+	}{
+		{time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC), 100, 300 / math.Sqrt(30)},
+		{time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), 6000.0 / 28, 300 / math.Sqrt(28)},
+		{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 6000.0 / 29, 300 / math.Sqrt(29)},
+		{time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), 3000.0 / 31, 300 / math.Sqrt(31)},
+	}
+	for _, tt := range tests {
+		if got := p.DailyRate(tt.date); !near(got, tt.rate) {
+			t.Errorf("DailyRate(%s) = %v, want %v", tt.date.Format("2006-01-02"), got, tt.rate)
+		}
+		if got := p.DailySpread(tt.date); !near(got, tt.spread) {
+			t.Errorf("DailySpread(%s) = %v, want %v", tt.date.Format("2006-01-02"), got, tt.spread)
+		}
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/gorilla/mux v1.8.1
 	github.com/swaggo/http-swagger v1.3.4
 	github.com/swaggo/swag v1.16.6
+	golang.org/x/image v0.29.0
 	gorm.io/driver/postgres v1.6.0
 	gorm.io/gorm v1.30.1
 )
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"cash-flow-go/anomaly"
+	db "cash-flow-go/database"
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+// Histori yang dipakai detektor
+const (
+	anomalyLookbackDays = 180
+	payeeHistoryLimit   = 12
+)
+
+// anomalyScanInterval dibaca dari ANOMALY_SCAN_INTERVAL (durasi Go, misal "30m").
+// Default 1 jam, "0" mematikan job periodik.
+func anomalyScanInterval() time.Duration {
+	v := os.Getenv("ANOMALY_SCAN_INTERVAL")
+	if v == "" {
+		return time.Hour
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Printf("ANOMALY_SCAN_INTERVAL %q tidak valid, pakai 1h", v)
+		return time.Hour
+	}
+	return d
+}
+
+func approvedExpenses() TransactionFilter {
+	return TransactionFilter{}.ForDashboard("pengeluaran")
+}
+
+// transactionAnomalies menjalankan detektor transaksi besar dan kenaikan harga untuk satu
+// transaksi, dibandingkan dengan histori kategori dan payee-nya sebelum transaksi itu.
+func transactionAnomalies(tx models.Transaction) ([]models.Anomaly, error) {
+	if tx.Type != "pengeluaran" || tx.Status != models.StatusApproved || tx.DebtID != nil {
+		return nil, nil
+	}
+
+	since := tx.TransactionAt.AddDate(0, 0, -anomalyLookbackDays)
+	history := func(column, value string, limit int) ([]float64, error) {
+		var amounts []float64
+		q := approvedExpenses().Apply(db.DB.Model(&models.Transaction{})).
+			Where("transactions."+column+" = ? AND transactions.id <> ?", value, tx.ID).
+			Where("transactions.transaction_at >= ? AND transactions.transaction_at <= ?", since, tx.TransactionAt).
+			Order("transactions.transaction_at DESC")
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		err := q.Pluck("amount", &amounts).Error
+		return amounts, err
+	}
+
+	var found []anomaly.Finding
+	if tx.Category != "" {
+		amounts, err := history("category", tx.Category, 0)
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := anomaly.LargeTransaction(tx.Amount, amounts, "kategori "+tx.Category); ok {
+			found = append(found, f)
+		}
+	}
+	if tx.Payee != "" {
+		amounts, err := history("payee", tx.Payee, payeeHistoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := anomaly.PriceIncrease(tx.Payee, tx.Amount, amounts); ok {
+			found = append(found, f)
+		} else if f, ok := anomaly.LargeTransaction(tx.Amount, amounts, tx.Payee); ok && len(found) == 0 {
+			found = append(found, f)
+		}
+	}
+
+	var list []models.Anomaly
+	for _, f := range found {
+		id := tx.ID
+		list = append(list, models.Anomaly{
+			Kind:          f.Kind,
+			DedupKey:      fmt.Sprintf("%s:tx:%d", f.Kind, tx.ID),
+			TransactionID: &id,
+			Category:      tx.Category,
+			Payee:         tx.Payee,
+			Period:        dayOf(tx.TransactionAt).Format("2006-01-02"),
+			Amount:        f.Amount,
+			Expected:      f.Expected,
+			Score:         f.Score,
+			Explanation:   f.Explanation,
+		})
+	}
+	return list, nil
+}
+
+// categorySpikes membandingkan pengeluaran bulan berjalan tiap kategori dengan total
+// bulanan 12 bulan sebelumnya. categories kosong berarti semua kategori.
+func categorySpikes(now time.Time, categories ...string) ([]models.Anomaly, error) {
+	local := now.In(appZone)
+	monthStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, appZone)
+	historyStart := monthStart.AddDate(0, -12, 0)
+
+	type row struct {
+		Category   string
+		MonthStart time.Time
+		Total      float64
+	}
+	filter := approvedExpenses()
+	filter.From = &historyStart
+	q := filter.Apply(db.DB.Model(&models.Transaction{})).
+		Select("transactions.category AS category, " + periodSQL("month") + " AS month_start, SUM(amount) AS total").
+		Where("transactions.category <> ''").
+		Group("category, month_start")
+	if len(categories) > 0 {
+		q = q.Where("transactions.category IN ?", categories)
+	}
+	var rows []row
+	if err := q.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	current := dayOf(monthStart)
+	monthToDate := map[string]float64{}
+	monthly := map[string][]float64{}
+	for _, r := range rows {
+		if r.MonthStart.Equal(current) {
+			monthToDate[r.Category] = r.Total
+		} else {
+			monthly[r.Category] = append(monthly[r.Category], r.Total)
+		}
+	}
+
+	period := current.Format("2006-01")
+	var list []models.Anomaly
+	for category, mtd := range monthToDate {
+		f, ok := anomaly.CategorySpike(category, mtd, monthly[category])
+		if !ok {
+			continue
+		}
+		list = append(list, models.Anomaly{
+			Kind:        f.Kind,
+			DedupKey:    fmt.Sprintf("%s:%s:%s", f.Kind, category, period),
+			Category:    category,
+			Period:      period,
+			Amount:      f.Amount,
+			Expected:    f.Expected,
+			Score:       f.Score,
+			Explanation: f.Explanation,
+		})
+	}
+	return list, nil
+}
+
+// detectAnomalies dipanggil setelah transaksi baru tersimpan / di-approve.
+// Error hanya di-log karena job periodik akan mengulang pengecekan.
+func detectAnomalies(tx models.Transaction) {
+	if tx.Type != "pengeluaran" || tx.Status != models.StatusApproved {
+		return
+	}
+	list, err := transactionAnomalies(tx)
+	if err == nil {
+		var spikes []models.Anomaly
+		spikes, err = categorySpikes(time.Now(), tx.Category)
+		list = append(list, spikes...)
+	}
+	var saved []models.Anomaly
+	if err == nil {
+		saved, err = db.SaveAnomalies(list)
+	}
+	publishAnomalies(saved)
+	if err != nil {
+		log.Printf("deteksi anomali transaksi %d gagal: %v", tx.ID, err)
+	}
+}
+
+// publishAnomalies mengirim anomali baru sebagai anomaly.detected. Feed anomali tidak
+// dibatasi per user, jadi event-nya global: dikirim ke semua workspace lewat SSE,
+// webhook dan notifikasi.
+func publishAnomalies(list []models.Anomaly) {
+	for _, a := range list {
+		publish(events.AnomalyDetected, Identity{}, a)
+	}
+}
+
+var scanMu sync.Mutex
+
+// scanAnomalies memeriksa semua transaksi pengeluaran yang masuk / di-approve sejak scan
+// terakhir (scan pertama: 24 jam terakhir) dan lonjakan semua kategori bulan ini.
+// Waktu scan terakhir disimpan di database supaya tidak hilang saat restart.
+func scanAnomalies() ([]models.Anomaly, error) {
+	scanMu.Lock()
+	defer scanMu.Unlock()
+
+	now := time.Now()
+	since, err := db.LastAnomalyScan()
+	if err != nil {
+		return nil, err
+	}
+	if since.IsZero() {
+		since = now.Add(-24 * time.Hour)
+	}
+
+	var txs []models.Transaction
+	err = approvedExpenses().Apply(db.DB.Model(&models.Transaction{})).
+		Where("(transactions.created_at >= ? OR transactions.decided_at >= ?)", since, since).
+		Find(&txs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var list []models.Anomaly
+	for _, tx := range txs {
+		found, err := transactionAnomalies(tx)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, found...)
+	}
+	spikes, err := categorySpikes(now)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, spikes...)
+
+	saved, err := db.SaveAnomalies(list)
+	publishAnomalies(saved)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.SaveAnomalyScan(now); err != nil {
+		return saved, err
+	}
+	return saved, nil
+}
+
+// StartAnomalyScanner menjalankan scanAnomalies secara periodik di background
+func StartAnomalyScanner() {
+	interval := anomalyScanInterval()
+	if interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if saved, err := scanAnomalies(); err != nil {
+				log.Printf("scan anomali gagal: %v", err)
+			} else if len(saved) > 0 {
+				log.Printf("scan anomali: %d anomali baru", len(saved))
+			}
+		}
+	}()
+}
+
+// GetAnomalies godoc
+// @Summary Feed anomali pengeluaran
+// @Description Pengeluaran tidak biasa beserta penjelasannya: large_transaction (jauh di atas median kategori / payee), category_spike (pengeluaran kategori bulan ini >= 3x median bulanan) dan price_increase (payee dengan harga stabil yang naik)
+// @Tags Anomalies
+// @Produce json
+// @Param kind query string false "large_transaction, category_spike atau price_increase"
+// @Param include_dismissed query bool false "Ikut tampilkan yang sudah di-dismiss"
+// @Param limit query int false "Jumlah maksimal (default 50, maksimal 200)"
+// @Success 200 {array} models.Anomaly
+// @Router /api/anomalies [get]
+func GetAnomalies(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit harus angka positif", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, 200)
+	}
+
+	list, err := db.ListAnomalies(q.Get("kind"), q.Get("include_dismissed") == "true", limit)
+	if err != nil {
+		http.Error(w, "Gagal mengambil anomali", http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = []models.Anomaly{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// DismissAnomaly godoc
+// @Summary Dismiss anomali
+// @Tags Anomalies
+// @Param id path int true "Anomaly ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/anomalies/{id}/dismiss [post]
+func DismissAnomaly(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := db.DismissAnomaly(id); err != nil {
+		if errors.Is(err, db.ErrAnomalyNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Gagal menyimpan", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Anomali di-dismiss"})
+}
+
+// ScanAnomalies godoc
+// @Summary Jalankan scan anomali sekarang
+// @Description Sama dengan job periodik: memeriksa transaksi sejak scan terakhir dan lonjakan kategori bulan ini
+// @Tags Anomalies
+// @Produce json
+// @Success 200 {array} models.Anomaly
+// @Router /api/anomalies/scan [post]
+func ScanAnomalies(w http.ResponseWriter, r *http.Request) {
+	saved, err := scanAnomalies()
+	if err != nil {
+		http.Error(w, "Gagal menjalankan scan", http.StatusInternalServerError)
+		return
+	}
+	if saved == nil {
+		saved = []models.Anomaly{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved)
+}
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ApprovalRequest struct {
+	Comment string `json:"comment" example:"Oke, sesuai budget"`
+}
+
+// approvalThreshold dibaca dari env APPROVAL_THRESHOLD.
+// Nilai 0 / kosong berarti approval tidak diwajibkan.
+func approvalThreshold() float64 {
+	v, err := strconv.ParseFloat(os.Getenv("APPROVAL_THRESHOLD"), 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
+
+func needsApproval(tx models.Transaction) bool {
+	threshold := approvalThreshold()
+	return threshold > 0 && tx.Type == "pengeluaran" && tx.Amount > threshold
+}
+
+// initialStatus menentukan status transaksi saat disubmit:
+// pengeluaran di atas threshold harus menunggu approval manager.
+func initialStatus(tx models.Transaction) string {
+	if needsApproval(tx) {
+		return models.StatusSubmitted
+	}
+	return models.StatusApproved
+}
+
+func recordApproval(dbtx *gorm.DB, txID uint, from, to, actor, comment string) error {
+	return dbtx.Create(&models.ApprovalHistory{
+		TransactionID: txID,
+		FromStatus:    from,
+		ToStatus:      to,
+		Actor:         actor,
+		Comment:       comment,
+		CreatedAt:     time.Now(),
+	}).Error
+}
+
+// changeStatus memindahkan status transaksi secara atomik.
+// Row di-lock supaya dua manager tidak memproses transaksi yang sama bersamaan.
+func changeStatus(id int, allowedFrom []string, actor Identity, comment string, next func(tx models.Transaction) string) (models.Transaction, int, string) {
+	var tx models.Transaction
+	status, msg := http.StatusOK, ""
+	affectsDashboard := false
+
+	err := db.DB.Transaction(func(dbtx *gorm.DB) error {
+		if err := dbtx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tx, id).Error; err != nil {
+			status, msg = http.StatusNotFound, "Transaksi tidak ditemukan"
+			return err
+		}
+
+		allowed := false
+		for _, s := range allowedFrom {
+			if tx.Status == s {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			status, msg = http.StatusConflict, "Status transaksi "+tx.Status+" tidak bisa diproses"
+			return errors.New(msg)
+		}
+
+		from := tx.Status
+		tx.Status = next(tx)
+		updates := map[string]interface{}{"status": tx.Status}
+		if tx.Status == models.StatusApproved || tx.Status == models.StatusRejected {
+			now := time.Now()
+			tx.ApprovedBy = actor.UserID
+			tx.ApprovalComment = comment
+			tx.DecidedAt = &now
+			updates["approved_by"] = tx.ApprovedBy
+			updates["approval_comment"] = tx.ApprovalComment
+			updates["decided_at"] = tx.DecidedAt
+		}
+
+		if err := dbtx.Model(&tx).Updates(updates).Error; err != nil {
+			status, msg = http.StatusInternalServerError, "Gagal mengubah status transaksi"
+			return err
+		}
+		if err := recordApproval(dbtx, tx.ID, from, tx.Status, actor.UserID, comment); err != nil {
+			status, msg = http.StatusInternalServerError, "Gagal mencatat riwayat approval"
+			return err
+		}
+		// Hanya transaksi approved yang masuk rollup dan cache dashboard
+		affectsDashboard = from == models.StatusApproved || tx.Status == models.StatusApproved
+		if affectsDashboard {
+			return refreshSummaries(dbtx, tx.TransactionAt)
+		}
+		return nil
+	})
+	if err != nil && msg == "" {
+		status, msg = http.StatusInternalServerError, "Gagal mengubah status transaksi"
+	}
+	if err == nil {
+		if affectsDashboard {
+			invalidateDashboardCache(tx.TransactionAt)
+		}
+		publishStatusChange(actor, tx, affectsDashboard)
+	}
+	return tx, status, msg
+}
+
+func transactionID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "ID tidak valid", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// SubmitTransaction godoc
+// @Summary Submit transaksi draft / rejected
+// @Description Mengajukan ulang transaksi. Pengeluaran di atas threshold menunggu approval, sisanya langsung approved.
+// @Tags Approval
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User-ID header string true "User yang mengajukan"
+// @Success 200 {object} models.Transaction
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/transactions/{id}/submit [post]
+func SubmitTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := transactionID(w, r)
+	if !ok {
+		return
+	}
+	actor := currentIdentity(r)
+
+	var existing models.Transaction
+	if err := db.DB.First(&existing, id).Error; err != nil {
+		http.Error(w, "Transaksi tidak ditemukan", http.StatusNotFound)
+		return
+	}
+	if existing.CreatedBy != "" && existing.CreatedBy != actor.UserID {
+		http.Error(w, "Hanya pembuat transaksi yang bisa submit", http.StatusForbidden)
+		return
+	}
+
+	tx, status, msg := changeStatus(id, []string{models.StatusDraft, models.StatusRejected}, actor, "",
+		func(tx models.Transaction) string { return initialStatus(tx) })
+	if msg != "" {
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tx)
+}
+
+// ApproveTransaction godoc
+// @Summary Approve pengeluaran
+// @Description Manager menyetujui transaksi yang sedang menunggu approval
+// @Tags Approval
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User-ID header string true "Manager"
+// @Param X-User-Role header string true "Harus manager"
+// @Param body body ApprovalRequest false "Komentar approver"
+// @Success 200 {object} models.Transaction
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/transactions/{id}/approve [post]
+func ApproveTransaction(w http.ResponseWriter, r *http.Request) {
+	decideTransaction(w, r, models.StatusApproved)
+}
+
+// RejectTransaction godoc
+// @Summary Reject pengeluaran
+// @Description Manager menolak transaksi yang sedang menunggu approval (komentar wajib)
+// @Tags Approval
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User-ID header string true "Manager"
+// @Param X-User-Role header string true "Harus manager"
+// @Param body body ApprovalRequest true "Alasan penolakan"
+// @Success 200 {object} models.Transaction
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/transactions/{id}/reject [post]
+func RejectTransaction(w http.ResponseWriter, r *http.Request) {
+	decideTransaction(w, r, models.StatusRejected)
+}
+
+func decideTransaction(w http.ResponseWriter, r *http.Request, decision string) {
+	id, ok := transactionID(w, r)
+	if !ok {
+		return
+	}
+
+	actor := currentIdentity(r)
+	if !actor.IsManager() || actor.UserID == "" {
+		http.Error(w, "Hanya manager yang bisa memproses approval", http.StatusForbidden)
+		return
+	}
+
+	var req ApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if decision == models.StatusRejected && req.Comment == "" {
+		http.Error(w, "Komentar wajib diisi saat menolak", http.StatusBadRequest)
+		return
+	}
+
+	var existing models.Transaction
+	if err := db.DB.First(&existing, id).Error; err != nil {
+		http.Error(w, "Transaksi tidak ditemukan", http.StatusNotFound)
+		return
+	}
+	if existing.CreatedBy != "" && existing.CreatedBy == actor.UserID {
+		http.Error(w, "Tidak bisa approve transaksi sendiri", http.StatusForbidden)
+		return
+	}
+
+	tx, status, msg := changeStatus(id, []string{models.StatusSubmitted}, actor, req.Comment,
+		func(models.Transaction) string { return decision })
+	if msg != "" {
+		http.Error(w, msg, status)
+		return
+	}
+	go detectAnomalies(tx)
+	go matchPayment(tx)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tx)
+}
+
+// GetPendingApprovals godoc
+// @Summary Antrian approval manager
+// @Description Daftar transaksi berstatus submitted, yang paling lama menunggu di atas
+// @Tags Approval
+// @Produce json
+// @Param X-User-Role header string true "Harus manager"
+// @Success 200 {object} map[string][]models.TransactionResponse
+// @Failure 403 {object} map[string]string
+// @Router /api/approvals/pending [get]
+func GetPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	if !currentIdentity(r).IsManager() {
+		http.Error(w, "Hanya manager yang bisa melihat antrian approval", http.StatusForbidden)
+		return
+	}
+
+	var txs []models.Transaction
+	if err := db.DB.
+		Where("status = ?", models.StatusSubmitted).
+		Order("created_at ASC").
+		Find(&txs).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pending": toTransactionResponses(txs),
+	})
+}
+
+// GetMyApprovals godoc
+// @Summary Status pengajuan milik user
+// @Description Daftar transaksi user yang masih draft, menunggu approval, atau ditolak
+// @Tags Approval
+// @Produce json
+// @Param X-User-ID header string true "User"
+// @Success 200 {object} map[string][]models.TransactionResponse
+// @Failure 401 {object} map[string]string
+// @Router /api/approvals/mine [get]
+func GetMyApprovals(w http.ResponseWriter, r *http.Request) {
+	actor := currentIdentity(r)
+	if actor.UserID == "" {
+		http.Error(w, "Header X-User-ID wajib diisi", http.StatusUnauthorized)
+		return
+	}
+
+	var txs []models.Transaction
+	if err := db.DB.
+		Where("created_by = ? AND status <> ?", actor.UserID, models.StatusApproved).
+		Order("created_at DESC").
+		Find(&txs).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"transactions": toTransactionResponses(txs),
+	})
+}
+
+// GetApprovalHistory godoc
+// @Summary Riwayat approval transaksi
+// @Description Semua perubahan status transaksi beserta komentar approver
+// @Tags Approval
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {array} models.ApprovalHistory
+// @Router /api/transactions/{id}/history [get]
+func GetApprovalHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := transactionID(w, r)
+	if !ok {
+		return
+	}
+
+	var history []models.ApprovalHistory
+	if err := db.DB.
+		Where("transaction_id = ?", id).
+		Order("created_at ASC").
+		Find(&history).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+	"cash-flow-go/storage"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	maxAttachmentSize     = 5 << 20
+	maxAttachmentsPerTx   = 10
+	maxAttachmentFormSize = 32 << 20
+)
+
+// Tipe file yang diizinkan, dicek dari isi file (bukan dari ekstensi / header client)
+var allowedAttachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// canAccessTransaction: pembuat transaksi dan manager boleh melihat lampiran.
+// Transaksi lama tanpa pembuat bisa diakses semua user yang teridentifikasi.
+func canAccessTransaction(id Identity, tx models.Transaction) bool {
+	if id.UserID == "" {
+		return false
+	}
+	return id.IsManager() || tx.CreatedBy == "" || tx.CreatedBy == id.UserID
+}
+
+func withDownloadURL(a models.Attachment) models.Attachment {
+	a.DownloadURL = fmt.Sprintf("/api/attachments/%d/download", a.ID)
+	return a
+}
+
+// sniffAttachment membaca 512 byte pertama untuk menentukan content type asli
+func sniffAttachment(file multipart.File) (string, error) {
+	head := make([]byte, 512)
+	n, err := file.Read(head)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+func saveAttachment(ctx context.Context, txID uint, fh *multipart.FileHeader, uploader string) (models.Attachment, int, error) {
+	if fh.Size > maxAttachmentSize {
+		return models.Attachment{}, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("%s melebihi batas %d MB", fh.Filename, maxAttachmentSize>>20)
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return models.Attachment{}, http.StatusBadRequest, fmt.Errorf("gagal membaca %s", fh.Filename)
+	}
+	defer file.Close()
+
+	contentType, err := sniffAttachment(file)
+	if err != nil {
+		return models.Attachment{}, http.StatusBadRequest, fmt.Errorf("gagal membaca %s", fh.Filename)
+	}
+	ext, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		return models.Attachment{}, http.StatusUnsupportedMediaType,
+			fmt.Errorf("%s bertipe %s, hanya JPEG, PNG, WebP dan PDF yang diizinkan", fh.Filename, contentType)
+	}
+
+	key := fmt.Sprintf("attachments/%d/%d%s", txID, time.Now().UnixNano(), ext)
+	if err := storage.Store.Put(ctx, key, file, fh.Size, contentType); err != nil {
+		return models.Attachment{}, http.StatusInternalServerError, fmt.Errorf("gagal menyimpan file")
+	}
+
+	att := models.Attachment{
+		TransactionID: txID,
+		FileName:      filepath.Base(fh.Filename),
+		ContentType:   contentType,
+		Size:          fh.Size,
+		StorageKey:    key,
+		UploadedBy:    uploader,
+		CreatedAt:     time.Now(),
+	}
+	if err := db.DB.Create(&att).Error; err != nil {
+		storage.Store.Delete(ctx, key)
+		return models.Attachment{}, http.StatusInternalServerError, fmt.Errorf("gagal menyimpan data lampiran")
+	}
+	return att, http.StatusCreated, nil
+}
+
+// loadAuthorizedTransaction mengambil transaksi dan memastikan user boleh mengaksesnya
+func loadAuthorizedTransaction(w http.ResponseWriter, r *http.Request, id int) (models.Transaction, bool) {
+	var tx models.Transaction
+	if err := db.DB.First(&tx, id).Error; err != nil {
+		http.Error(w, "Transaksi tidak ditemukan", http.StatusNotFound)
+		return tx, false
+	}
+	identity := currentIdentity(r)
+	if identity.UserID == "" {
+		http.Error(w, "Header X-User-ID wajib diisi", http.StatusUnauthorized)
+		return tx, false
+	}
+	if !canAccessTransaction(identity, tx) {
+		http.Error(w, "Tidak punya akses ke transaksi ini", http.StatusForbidden)
+		return tx, false
+	}
+	return tx, true
+}
+
+// loadAuthorizedAttachment mengambil lampiran beserta pengecekan akses transaksinya
+func loadAuthorizedAttachment(w http.ResponseWriter, r *http.Request) (models.Attachment, bool) {
+	var att models.Attachment
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "ID tidak valid", http.StatusBadRequest)
+		return att, false
+	}
+	if err := db.DB.First(&att, id).Error; err != nil {
+		http.Error(w, "Lampiran tidak ditemukan", http.StatusNotFound)
+		return att, false
+	}
+	if _, ok := loadAuthorizedTransaction(w, r, int(att.TransactionID)); !ok {
+		return att, false
+	}
+	return att, true
+}
+
+// UploadAttachments godoc
+// @Summary Upload lampiran transaksi
+// @Description Mengunggah satu atau beberapa struk (JPEG, PNG, WebP, PDF, maks 5MB per file) ke sebuah transaksi
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User-ID header string true "User"
+// @Param files formData file true "File lampiran (boleh lebih dari satu)"
+// @Success 201 {array} models.Attachment
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Router /api/transactions/{id}/attachments [post]
+func UploadAttachments(w http.ResponseWriter, r *http.Request) {
+	id, ok := transactionID(w, r)
+	if !ok {
+		return
+	}
+	tx, ok := loadAuthorizedTransaction(w, r, id)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentFormSize)
+	if err := r.ParseMultipartForm(maxAttachmentFormSize); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	files := r.MultipartForm.File["files"]
+	if len(files) == 0 {
+		http.Error(w, "Minimal satu file wajib diunggah", http.StatusBadRequest)
+		return
+	}
+
+	var existing int64
+	db.DB.Model(&models.Attachment{}).Where("transaction_id = ?", tx.ID).Count(&existing)
+	if int(existing)+len(files) > maxAttachmentsPerTx {
+		http.Error(w, fmt.Sprintf("Maksimal %d lampiran per transaksi", maxAttachmentsPerTx), http.StatusBadRequest)
+		return
+	}
+
+	uploader := currentIdentity(r).UserID
+	var saved []models.Attachment
+	for _, fh := range files {
+		att, status, err := saveAttachment(r.Context(), tx.ID, fh, uploader)
+		if err != nil {
+			// Batalkan file yang sudah tersimpan supaya upload bersifat all-or-nothing
+			for _, s := range saved {
+				deleteAttachment(s)
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		saved = append(saved, withDownloadURL(att))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// GetAttachments godoc
+// @Summary Daftar lampiran transaksi
+// @Tags Attachments
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User-ID header string true "User"
+// @Success 200 {array} models.Attachment
+// @Failure 403 {object} map[string]string
+// @Router /api/transactions/{id}/attachments [get]
+func GetAttachments(w http.ResponseWriter, r *http.Request) {
+	id, ok := transactionID(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := loadAuthorizedTransaction(w, r, id); !ok {
+		return
+	}
+
+	var atts []models.Attachment
+	if err := db.DB.Where("transaction_id = ?", id).Order("created_at ASC").Find(&atts).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for i := range atts {
+		atts[i] = withDownloadURL(atts[i])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(atts)
+}
+
+// DownloadAttachment godoc
+// @Summary Download lampiran
+// @Description Mengunduh file lampiran, hanya untuk pembuat transaksi atau manager
+// @Tags Attachments
+// @Produce octet-stream
+// @Param id path int true "Attachment ID"
+// @Param X-User-ID header string true "User"
+// @Success 200 {file} file
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/attachments/{id}/download [get]
+func DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	att, ok := loadAuthorizedAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := storage.Store.Open(r.Context(), att.StorageKey)
+	if err != nil {
+		http.Error(w, "File lampiran tidak ditemukan", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", att.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", att.FileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// Backend lokal mengembalikan file yang bisa di-seek, jadi Range dan request
+	// kondisional tetap didukung; S3 di-stream apa adanya
+	if rs, ok := f.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, att.FileName, att.CreatedAt, rs)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(att.Size, 10))
+	if _, err := io.Copy(w, f); err != nil {
+		log.Printf("kirim lampiran %d gagal: %v", att.ID, err)
+	}
+}
+
+// DeleteAttachment godoc
+// @Summary Hapus lampiran
+// @Tags Attachments
+// @Param id path int true "Attachment ID"
+// @Param X-User-ID header string true "User"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/attachments/{id} [delete]
+func DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	att, ok := loadAuthorizedAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	if err := deleteAttachment(att); err != nil {
+		http.Error(w, "Gagal menghapus lampiran", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Lampiran berhasil dihapus"})
+}
+
+func deleteAttachment(att models.Attachment) error {
+	if err := db.DB.Delete(&att).Error; err != nil {
+		return err
+	}
+	return storage.Store.Delete(context.Background(), att.StorageKey)
+}
+
+// deleteTransactionAttachments dipanggil di dalam DB transaction penghapusan transaksi.
+// Hanya row lampiran yang dihapus; storage key-nya dikembalikan supaya file baru dihapus
+// (removeAttachmentFiles) setelah commit, sehingga lampiran tidak hilang jika penghapusan gagal.
+func deleteTransactionAttachments(dbtx *gorm.DB, txID uint) ([]string, error) {
+	var keys []string
+	if err := dbtx.Model(&models.Attachment{}).Where("transaction_id = ?", txID).
+		Pluck("storage_key", &keys).Error; err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return keys, dbtx.Where("transaction_id = ?", txID).Delete(&models.Attachment{}).Error
+}
+
+// removeAttachmentFiles menghapus file lampiran dari storage. Kegagalan hanya di-log
+// karena row-nya sudah terhapus; file yang tersisa adalah file yatim.
+func removeAttachmentFiles(keys []string) {
+	for _, key := range keys {
+		if err := storage.Store.Delete(context.Background(), key); err != nil {
+			log.Printf("file lampiran yatim %s: %v", key, err)
+		}
+	}
+}
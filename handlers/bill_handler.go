@@ -0,0 +1,679 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/events"
+	"cash-flow-go/forecast"
+	"cash-flow-go/models"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// overdueReminderDays adalah batas pengingat overdue dikirim jika scanner sempat mati
+	overdueReminderDays = 7
+	// billLookbackDays adalah seberapa jauh ke belakang tagihan belum dibayar masih ditampilkan
+	billLookbackDays = 60
+	// billEstimateSamples adalah jumlah pembayaran terakhir untuk perkiraan tagihan estimated
+	billEstimateSamples = 3
+	// billMatchBefore / billMatchAfter adalah rentang pembayaran yang dianggap untuk satu periode
+	billMatchBefore = 25
+	billMatchAfter  = 20
+)
+
+// BillRequest adalah body untuk membuat / mengubah tagihan. Saat update semua field opsional.
+type BillRequest struct {
+	Name         *string  `json:"name" example:"Listrik PLN"`
+	Category     *string  `json:"category" example:"listrik"`
+	Payee        *string  `json:"payee" example:"PLN"`
+	Account      *string  `json:"account" example:"bca"`
+	Amount       *float64 `json:"amount" example:"450000"`
+	AmountType   *string  `json:"amount_type" example:"estimated"`
+	DueDay       *int     `json:"due_day" example:"20"`
+	ReminderDays *int     `json:"reminder_days" example:"3"`
+	IsActive     *bool    `json:"is_active" example:"true"`
+}
+
+// BillPaymentRequest menandai tagihan satu periode lunas secara manual. period default
+// periode dengan jatuh tempo terdekat yang belum dibayar; amount default perkiraan tagihan.
+type BillPaymentRequest struct {
+	Period        string  `json:"period,omitempty" example:"2025-09"`
+	Amount        float64 `json:"amount,omitempty" example:"432000"`
+	TransactionID *uint   `json:"transaction_id,omitempty" example:"91"`
+	PaidAt        string  `json:"paid_at,omitempty" example:"2025-09-18"`
+}
+
+// billReminderInterval dibaca dari BILL_REMINDER_INTERVAL (durasi Go, misal "30m").
+// Default 1 jam, "0" mematikan pengingat.
+func billReminderInterval() time.Duration {
+	v := os.Getenv("BILL_REMINDER_INTERVAL")
+	if v == "" {
+		return time.Hour
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Printf("BILL_REMINDER_INTERVAL %q tidak valid, pakai 1h", v)
+		return time.Hour
+	}
+	return d
+}
+
+func (req BillRequest) apply(b *models.Bill) error {
+	if req.Name != nil {
+		b.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Category != nil {
+		b.Category = strings.ToLower(strings.TrimSpace(*req.Category))
+	}
+	if req.Payee != nil {
+		b.Payee = strings.TrimSpace(*req.Payee)
+	}
+	if req.Account != nil {
+		b.Account = *req.Account
+	}
+	if req.Amount != nil {
+		b.Amount = *req.Amount
+	}
+	if req.AmountType != nil {
+		b.AmountType = *req.AmountType
+	}
+	if req.DueDay != nil {
+		b.DueDay = *req.DueDay
+	}
+	if req.ReminderDays != nil {
+		b.ReminderDays = *req.ReminderDays
+	}
+	if req.IsActive != nil {
+		b.IsActive = *req.IsActive
+	}
+
+	switch {
+	case b.Name == "":
+		return errors.New("name wajib diisi")
+	case b.Amount < 0 || (b.AmountType == models.BillFixed && b.Amount == 0):
+		return errors.New("amount harus lebih dari 0 untuk tagihan fixed")
+	case b.AmountType != models.BillFixed && b.AmountType != models.BillEstimated:
+		return errors.New("amount_type harus fixed atau estimated")
+	case b.DueDay < 1 || b.DueDay > 31:
+		return errors.New("due_day harus 1 sampai 31")
+	case b.ReminderDays < 0 || b.ReminderDays > 28:
+		return errors.New("reminder_days harus 0 sampai 28")
+	}
+	return nil
+}
+
+func writeBillError(w http.ResponseWriter, err error) {
+	var invalid *db.TransactionLinkError
+	switch {
+	case errors.As(err, &invalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, db.ErrBillNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, db.ErrBillPaymentExists), errors.Is(err, db.ErrBillPaymentNotFound):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Gagal menyimpan tagihan", http.StatusInternalServerError)
+	}
+}
+
+func billOwner(b models.Bill) Identity {
+	return Identity{UserID: b.CreatedBy, Workspace: b.Workspace}
+}
+
+// billEstimate adalah nominal yang diharapkan: Amount untuk fixed, rata-rata beberapa
+// pembayaran terakhir untuk estimated (Amount jika belum ada riwayat)
+func billEstimate(b models.Bill) float64 {
+	if b.AmountType == models.BillFixed {
+		return b.Amount
+	}
+	amounts, err := db.RecentBillAmounts(b.ID, billEstimateSamples)
+	if err != nil || len(amounts) == 0 {
+		return b.Amount
+	}
+	var sum float64
+	for _, a := range amounts {
+		sum += a
+	}
+	return sum / float64(len(amounts))
+}
+
+// billPeriods mengembalikan jatuh tempo tagihan di setiap bulan yang bersinggungan dengan
+// from - to, hanya yang jatuh di dalam rentang dan tidak sebelum tagihan dibuat
+func billPeriods(b models.Bill, from, to time.Time) []time.Time {
+	created := dayOf(b.CreatedAt)
+	var dues []time.Time
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !m.After(to); m = m.AddDate(0, 1, 0) {
+		due := b.DueDate(m)
+		if due.Before(from) || due.After(to) || due.Before(created) {
+			continue
+		}
+		dues = append(dues, due)
+	}
+	return dues
+}
+
+func periodOf(due time.Time) string {
+	return due.Format("2006-01")
+}
+
+// billPayments mengambil pembayaran tagihan pada periode-periode jatuh tempo dues, key periode
+func billPayments(billID uint, dues []time.Time) (map[string]models.BillPayment, error) {
+	periods := make([]string, len(dues))
+	for i, due := range dues {
+		periods[i] = periodOf(due)
+	}
+	paid, err := db.BillPayments([]uint{billID}, periods)
+	if err != nil {
+		return nil, err
+	}
+	return paid[billID], nil
+}
+
+// reminderKind menentukan pengingat yang berlaku hari ini untuk jatuh tempo due
+func reminderKind(due, today time.Time, reminderDays int) (string, bool) {
+	switch {
+	case today.Equal(due):
+		return models.ReminderDue, true
+	case today.After(due):
+		return models.ReminderOverdue, !today.After(due.AddDate(0, 0, overdueReminderDays))
+	default:
+		return models.ReminderUpcoming, reminderDays > 0 && !today.Before(due.AddDate(0, 0, -reminderDays))
+	}
+}
+
+var reminderEvents = map[string]string{
+	models.ReminderUpcoming: events.BillUpcoming,
+	models.ReminderDue:      events.BillDue,
+	models.ReminderOverdue:  events.BillOverdue,
+}
+
+// scanBillReminders mengirim pengingat untuk tagihan yang mendekati / lewat jatuh tempo
+// dan belum dibayar. Setiap pengingat hanya dikirim sekali per periode.
+func scanBillReminders(now time.Time) (int, error) {
+	bills, err := db.ListBills(true)
+	if err != nil {
+		return 0, err
+	}
+	today := dayOf(now)
+
+	sent := 0
+	for _, b := range bills {
+		dues := billPeriods(b, today.AddDate(0, 0, -overdueReminderDays), today.AddDate(0, 0, b.ReminderDays))
+		if len(dues) == 0 {
+			continue
+		}
+		paid, err := billPayments(b.ID, dues)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, due := range dues {
+			if _, ok := paid[periodOf(due)]; ok {
+				continue
+			}
+			kind, ok := reminderKind(due, today, b.ReminderDays)
+			if !ok {
+				continue
+			}
+			isNew, err := db.RecordBillReminder(&models.BillReminder{BillID: b.ID, Period: periodOf(due), Kind: kind})
+			if err != nil {
+				return sent, err
+			}
+			if !isNew {
+				continue
+			}
+			publish(reminderEvents[kind], billOwner(b), upcomingBill(b, due, today, billEstimate(b), nil))
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// StartBillReminders menjalankan pengecekan pengingat tagihan secara berkala
+func StartBillReminders() {
+	interval := billReminderInterval()
+	if interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			if sent, err := scanBillReminders(time.Now()); err != nil {
+				log.Printf("pengingat tagihan gagal: %v", err)
+			} else if sent > 0 {
+				log.Printf("pengingat tagihan: %d terkirim", sent)
+			}
+		}
+	}()
+}
+
+func upcomingBill(b models.Bill, due, today time.Time, estimate float64, payment *models.BillPayment) models.UpcomingBill {
+	u := models.UpcomingBill{
+		BillID:    b.ID,
+		Name:      b.Name,
+		Category:  b.Category,
+		Period:    periodOf(due),
+		DueDate:   due.Format("2006-01-02"),
+		DaysUntil: int(due.Sub(today).Hours() / 24),
+		Amount:    estimate,
+		Estimated: b.AmountType == models.BillEstimated,
+		Status:    models.BillUnpaid,
+		Payment:   payment,
+	}
+	switch {
+	case payment != nil:
+		u.Status = models.BillPaid
+		u.Amount = payment.Amount
+		u.Estimated = false
+	case due.Before(today):
+		u.Status = models.BillOverdue
+	}
+	return u
+}
+
+// matchBill dipanggil untuk transaksi pengeluaran approved yang bukan cicilan: jika payee-nya
+// sama dengan tagihan aktif dan nominalnya masuk akal, periode dengan jatuh tempo terdekat
+// yang belum dibayar ditandai lunas.
+func matchBill(tx models.Transaction) {
+	if tx.Payee == "" {
+		return
+	}
+	bills, err := db.ActiveBillsByPayee(tx.Payee)
+	if err != nil {
+		log.Printf("pencocokan tagihan untuk transaksi %d gagal: %v", tx.ID, err)
+		return
+	}
+
+	paidDay := dayOf(tx.TransactionAt)
+	for _, b := range bills {
+		if b.Account != "" && b.Account != tx.Account {
+			continue
+		}
+		estimate := billEstimate(b)
+		if !b.Accepts(tx.Amount, estimate) {
+			continue
+		}
+
+		dues := billPeriods(b, paidDay.AddDate(0, 0, -billMatchAfter), paidDay.AddDate(0, 0, billMatchBefore))
+		paid, err := billPayments(b.ID, dues)
+		if err != nil {
+			log.Printf("pencocokan tagihan untuk transaksi %d gagal: %v", tx.ID, err)
+			return
+		}
+
+		// Periode belum dibayar yang jatuh temponya paling dekat dengan tanggal bayar
+		var best *time.Time
+		for i, due := range dues {
+			if _, ok := paid[periodOf(due)]; ok {
+				continue
+			}
+			if best == nil || absDays(due, paidDay) < absDays(*best, paidDay) {
+				best = &dues[i]
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		txID := tx.ID
+		payment := models.BillPayment{BillID: b.ID, Period: periodOf(*best), Amount: tx.Amount, PaidAt: tx.TransactionAt, TransactionID: &txID}
+		if err := db.SaveBillPayment(&payment); err != nil {
+			if !errors.Is(err, db.ErrBillPaymentExists) {
+				log.Printf("pencocokan tagihan untuk transaksi %d gagal: %v", tx.ID, err)
+			}
+			return
+		}
+		publish(events.BillPaid, billOwner(b), upcomingBill(b, *best, dayOf(time.Now()), estimate, &payment))
+		return
+	}
+}
+
+func absDays(a, b time.Time) float64 {
+	d := a.Sub(b).Hours() / 24
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// matchPayment mencocokkan transaksi pengeluaran approved dengan cicilan, lalu tagihan.
+// Dijalankan berurutan supaya satu transaksi tidak tercatat di keduanya.
+func matchPayment(tx models.Transaction) {
+	if tx.Type != "pengeluaran" || tx.Status != models.StatusApproved {
+		return
+	}
+	if matchInstallment(tx) {
+		return
+	}
+	matchBill(tx)
+}
+
+// billEvents memasukkan tagihan yang belum dibayar ke forecast dengan nominal perkiraannya.
+// Tagihan yang sudah lewat jatuh tempo tapi belum dibayar dianggap dibayar di hari pertama proyeksi.
+func billEvents(from, to time.Time) ([]forecast.Event, error) {
+	bills, err := db.ListBills(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []forecast.Event
+	for _, b := range bills {
+		dues := billPeriods(b, from.AddDate(0, 0, -billLookbackDays), to)
+		paid, err := billPayments(b.ID, dues)
+		if err != nil {
+			return nil, err
+		}
+
+		estimate := billEstimate(b)
+		for _, due := range dues {
+			if _, ok := paid[periodOf(due)]; ok {
+				continue
+			}
+			date := due
+			if date.Before(from) {
+				date = from
+			}
+			list = append(list, forecast.Event{
+				Date:        date,
+				Amount:      -estimate,
+				Source:      "bill",
+				SourceID:    b.ID,
+				Description: b.Name + " " + periodOf(due),
+			})
+		}
+	}
+	return list, nil
+}
+
+// CreateBill godoc
+// @Summary Tambah tagihan bulanan
+// @Description amount_type fixed (nominal tetap) atau estimated (perkiraan; setelah ada pembayaran, perkiraan memakai rata-rata 3 pembayaran terakhir). Pengingat bill.upcoming dikirim reminder_days sebelum jatuh tempo, bill.due di hari jatuh tempo dan bill.overdue setelahnya, selama belum ada transaksi pembayaran.
+// @Tags Bills
+// @Accept json
+// @Produce json
+// @Param bill body BillRequest true "Tagihan"
+// @Success 201 {object} models.Bill
+// @Failure 400 {object} map[string]string
+// @Router /api/bills [post]
+func CreateBill(w http.ResponseWriter, r *http.Request) {
+	var req BillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	actor := currentIdentity(r)
+	b := models.Bill{AmountType: models.BillFixed, ReminderDays: 3, IsActive: true, CreatedBy: actor.UserID, Workspace: actor.Workspace}
+	if err := req.apply(&b); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if b.Payee == "" {
+		b.Payee = b.Name
+	}
+
+	if err := db.DB.Create(&b).Error; err != nil {
+		http.Error(w, "Gagal menyimpan tagihan", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(b)
+}
+
+// GetBills godoc
+// @Summary Daftar tagihan
+// @Tags Bills
+// @Produce json
+// @Success 200 {array} models.Bill
+// @Router /api/bills [get]
+func GetBills(w http.ResponseWriter, r *http.Request) {
+	bills, err := db.ListBills(false)
+	if err != nil {
+		http.Error(w, "Gagal mengambil tagihan", http.StatusInternalServerError)
+		return
+	}
+	if bills == nil {
+		bills = []models.Bill{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bills)
+}
+
+// UpdateBill godoc
+// @Summary Ubah tagihan
+// @Description Semua field opsional. is_active false menghentikan pengingat.
+// @Tags Bills
+// @Accept json
+// @Produce json
+// @Param id path int true "Bill ID"
+// @Param bill body BillRequest true "Perubahan"
+// @Success 200 {object} models.Bill
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/bills/{id} [put]
+func UpdateBill(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req BillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var invalid error
+	b, err := db.UpdateBill(id, func(b *models.Bill) error {
+		invalid = req.apply(b)
+		return invalid
+	})
+	if invalid != nil {
+		http.Error(w, invalid.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeBillError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(b)
+}
+
+// DeleteBill godoc
+// @Summary Hapus tagihan
+// @Description Riwayat pembayaran ikut dihapus; transaksinya tetap ada
+// @Tags Bills
+// @Param id path int true "Bill ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/bills/{id} [delete]
+func DeleteBill(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.DeleteBill(id); err != nil {
+		writeBillError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Tagihan berhasil dihapus"})
+}
+
+// GetUpcomingBills godoc
+// @Summary Tagihan yang akan datang dan yang terlewat
+// @Description Untuk dashboard: tagihan belum dibayar yang jatuh tempo dalam days hari ke depan, ditambah yang sudah lewat jatuh tempo (sampai 60 hari ke belakang). Urut jatuh tempo.
+// @Tags Bills
+// @Produce json
+// @Param days query int false "Jumlah hari ke depan (default 30, maksimal 90)"
+// @Param include_paid query bool false "Ikut tampilkan periode yang sudah dibayar"
+// @Success 200 {array} models.UpcomingBill
+// @Failure 400 {object} map[string]string
+// @Router /api/bills/upcoming [get]
+func GetUpcomingBills(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "days harus angka positif", http.StatusBadRequest)
+			return
+		}
+		days = min(n, 90)
+	}
+	includePaid := r.URL.Query().Get("include_paid") == "true"
+
+	bills, err := db.ListBills(true)
+	if err != nil {
+		http.Error(w, "Gagal mengambil tagihan", http.StatusInternalServerError)
+		return
+	}
+
+	today := dayOf(time.Now())
+	from, to := today.AddDate(0, 0, -billLookbackDays), today.AddDate(0, 0, days)
+
+	list := []models.UpcomingBill{}
+	for _, b := range bills {
+		dues := billPeriods(b, from, to)
+		paid, err := billPayments(b.ID, dues)
+		if err != nil {
+			http.Error(w, "Gagal mengambil pembayaran tagihan", http.StatusInternalServerError)
+			return
+		}
+
+		estimate := billEstimate(b)
+		for _, due := range dues {
+			var payment *models.BillPayment
+			if p, ok := paid[periodOf(due)]; ok {
+				// Periode lama yang sudah dibayar tidak perlu ditampilkan
+				if !includePaid || due.Before(today) {
+					continue
+				}
+				payment = &p
+			}
+			list = append(list, upcomingBill(b, due, today, estimate, payment))
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].DueDate < list[j].DueDate })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// PayBill godoc
+// @Summary Tandai tagihan satu periode sudah dibayar
+// @Description Untuk pembayaran yang tidak tercocokkan otomatis (payee berbeda, autodebet, dibayar orang lain). transaction_id harus pengeluaran approved yang belum dipakai tagihan, cicilan atau hutang piutang.
+// @Tags Bills
+// @Accept json
+// @Produce json
+// @Param id path int true "Bill ID"
+// @Param payment body BillPaymentRequest true "Pembayaran"
+// @Success 201 {object} models.BillPayment
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/bills/{id}/pay [post]
+func PayBill(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req BillPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b, err := db.GetBill(id)
+	if err != nil {
+		writeBillError(w, err)
+		return
+	}
+
+	today := dayOf(time.Now())
+	payment := models.BillPayment{BillID: b.ID, Period: req.Period, Amount: req.Amount, PaidAt: time.Now(), TransactionID: req.TransactionID}
+	if req.PaidAt != "" {
+		if payment.PaidAt, err = parseDate(req.PaidAt); err != nil {
+			http.Error(w, "paid_at harus format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var due time.Time
+	if payment.Period == "" {
+		// Periode belum dibayar paling awal, mulai dari yang terlewat
+		dues := billPeriods(*b, today.AddDate(0, 0, -billLookbackDays), today.AddDate(0, 1, 0))
+		paid, err := billPayments(b.ID, dues)
+		if err != nil {
+			writeBillError(w, err)
+			return
+		}
+		for _, d := range dues {
+			if _, ok := paid[periodOf(d)]; !ok {
+				due = d
+				break
+			}
+		}
+		if due.IsZero() {
+			http.Error(w, "Tidak ada tagihan yang belum dibayar", http.StatusConflict)
+			return
+		}
+		payment.Period = periodOf(due)
+	} else {
+		month, err := time.Parse("2006-01", payment.Period)
+		if err != nil {
+			http.Error(w, "period harus format YYYY-MM", http.StatusBadRequest)
+			return
+		}
+		due = b.DueDate(month)
+	}
+	if payment.Amount <= 0 {
+		payment.Amount = billEstimate(*b)
+	}
+
+	if err := db.SaveBillPayment(&payment); err != nil {
+		writeBillError(w, err)
+		return
+	}
+	publish(events.BillPaid, billOwner(*b), upcomingBill(*b, due, today, payment.Amount, &payment))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(payment)
+}
+
+// UnpayBill godoc
+// @Summary Batalkan pembayaran tagihan satu periode
+// @Tags Bills
+// @Param id path int true "Bill ID"
+// @Param period path string true "Periode YYYY-MM"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/bills/{id}/payments/{period} [delete]
+func UnpayBill(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.DeleteBillPayment(id, mux.Vars(r)["period"]); err != nil {
+		writeBillError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Pembayaran tagihan dibatalkan"})
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+)
+
+// Dimensi breakdown dashboard
+const (
+	BreakdownCategory = "category"
+	BreakdownAccount  = "account"
+	BreakdownPayee    = "payee"
+	BreakdownWeekday  = "weekday"
+	BreakdownHour     = "hour"
+)
+
+// OthersKey adalah key irisan gabungan di luar top-N
+const OthersKey = "others"
+
+const defaultBreakdownTop = 5
+
+// breakdownKeySQL mengembalikan ekspresi SQL untuk key tiap dimensi.
+// Transaksi tanpa categories dihitung dengan kolom category utamanya.
+func breakdownKeySQL(by string) (string, bool) {
+	switch by {
+	case BreakdownCategory:
+		return "unnest(CASE WHEN COALESCE(cardinality(transactions.categories), 0) > 0 " +
+			"THEN transactions.categories ELSE ARRAY[transactions.category] END)", true
+	case BreakdownAccount:
+		return "transactions.account", true
+	case BreakdownPayee:
+		return "transactions.payee", true
+	case BreakdownWeekday:
+		return "EXTRACT(ISODOW FROM " + localTimeSQL() + ")::int::text", true
+	case BreakdownHour:
+		return "EXTRACT(HOUR FROM " + localTimeSQL() + ")::int::text", true
+	}
+	return "", false
+}
+
+// queryBreakdown menjumlahkan amount per key, urut dari total terbesar
+func queryBreakdown(filter TransactionFilter, by string) ([]models.BreakdownItem, error) {
+	keySQL, ok := breakdownKeySQL(by)
+	if !ok {
+		return nil, errors.New("by harus category, account, payee, weekday atau hour")
+	}
+
+	// Category dan account bisa dijawab dari rollup harian
+	var q *gorm.DB
+	switch {
+	case by == BreakdownCategory && filter.usesSummaries(true):
+		q = filter.applySummary(db.DB.Model(&models.DailyCategorySummary{})).
+			Select("category AS key, SUM(total) AS total, SUM(count) AS count")
+	case by == BreakdownAccount && filter.usesSummaries(false):
+		q = filter.applySummary(db.DB.Model(&models.DailySummary{})).
+			Select("account AS key, SUM(total) AS total, SUM(count) AS count")
+	default:
+		q = filter.Apply(db.DB.Model(&models.Transaction{})).
+			Select(keySQL + " AS key, SUM(amount) AS total, COUNT(*) AS count")
+	}
+
+	var items []models.BreakdownItem
+	err := q.Group("key").
+		Order("total DESC, key").
+		Scan(&items).Error
+	return items, err
+}
+
+// summarizeBreakdown menghitung persentase dan menggabungkan irisan setelah top-N ke "others".
+// top <= 0 berarti tanpa batas.
+func summarizeBreakdown(items []models.BreakdownItem, top int) (float64, []models.BreakdownItem) {
+	var total float64
+	for _, it := range items {
+		total += it.Total
+	}
+
+	if top > 0 && len(items) > top {
+		others := models.BreakdownItem{Key: OthersKey}
+		for _, it := range items[top:] {
+			others.Total += it.Total
+			others.Count += it.Count
+		}
+		items = append(items[:top:top], others)
+	}
+
+	for i := range items {
+		if total != 0 {
+			items[i].Percentage = items[i].Total / total * 100
+		}
+	}
+	return total, items
+}
+
+// labelTimeBuckets mengubah key weekday (ISODOW 1-7) jadi nama hari dan key hour jadi "HH:00".
+// Jika byTime true, item diurutkan sesuai urutan waktu, bukan total.
+func labelTimeBuckets(items []models.BreakdownItem, by string, byTime bool) {
+	if byTime {
+		sort.SliceStable(items, func(i, j int) bool {
+			a, _ := strconv.Atoi(items[i].Key)
+			b, _ := strconv.Atoi(items[j].Key)
+			return a < b
+		})
+	}
+
+	for i := range items {
+		n, err := strconv.Atoi(items[i].Key)
+		if err != nil {
+			continue // others
+		}
+		if by == BreakdownWeekday {
+			items[i].Key = time.Weekday(n % 7).String()
+		} else {
+			items[i].Key = fmt.Sprintf("%02d:00", n)
+		}
+	}
+}
+
+// GetBreakdown godoc
+// @Summary Breakdown pemasukan / pengeluaran
+// @Description Total dan persentase per category, account, payee, weekday atau hour (zona APP_TIMEZONE). Category memecah categories sehingga transaksi multi-kategori dihitung di tiap kategorinya dan persentase dihitung dari total irisan. Untuk category/account/payee irisan setelah top-N (default 5) digabung ke "others". Weekday dan hour diurutkan sesuai waktu kecuali top dikirim.
+// @Tags Dashboard
+// @Produce json
+// @Param by query string false "category (default), account, payee, weekday atau hour"
+// @Param type query string false "pemasukan atau pengeluaran (default pengeluaran)"
+// @Param top query int false "Jumlah irisan sebelum digabung ke others (0 = semua)"
+// @Param period query string false "Preset periode (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
+// @Success 200 {object} models.BreakdownResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/dashboard/breakdown [get]
+func GetBreakdown(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter = filter.ForDashboard("pengeluaran")
+
+	by := r.URL.Query().Get("by")
+	if by == "" {
+		by = BreakdownCategory
+	}
+	if _, ok := breakdownKeySQL(by); !ok {
+		http.Error(w, "by harus category, account, payee, weekday atau hour", http.StatusBadRequest)
+		return
+	}
+	timeBucket := by == BreakdownWeekday || by == BreakdownHour
+
+	top := defaultBreakdownTop
+	if timeBucket {
+		top = 0
+	}
+	if v := r.URL.Query().Get("top"); v != "" {
+		if top, err = strconv.Atoi(v); err != nil || top < 0 {
+			http.Error(w, "top harus angka >= 0", http.StatusBadRequest)
+			return
+		}
+	}
+
+	items, err := queryBreakdown(filter, by)
+	if err != nil {
+		http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+		return
+	}
+
+	total, items := summarizeBreakdown(items, top)
+	if timeBucket {
+		labelTimeBuckets(items, by, top == 0)
+	}
+	if items == nil {
+		items = []models.BreakdownItem{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.BreakdownResponse{
+		By:    by,
+		Type:  filter.Type,
+		Total: total,
+		Items: items,
+	})
+}
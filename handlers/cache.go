@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"cash-flow-go/cache"
+)
+
+// Rentang lebih dari ini dianggap bergantung pada semua bulan
+const maxCacheMonths = 36
+
+// cacheMonths mengembalikan bulan (YYYY-MM, zona aplikasi) yang dicakup filter,
+// nil jika rentangnya terbuka atau terlalu panjang.
+func cacheMonths(f TransactionFilter) []string {
+	if f.From == nil || f.To == nil {
+		return nil
+	}
+	from := f.From.In(appZone)
+	last := f.To.Add(-time.Nanosecond).In(appZone)
+
+	months := []string{}
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, appZone); !m.After(last); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format("2006-01"))
+		if len(months) > maxCacheMonths {
+			return nil
+		}
+	}
+	return months
+}
+
+// cacheKeyPart menyusun representasi filter yang stabil (rentang sudah di-resolve,
+// jadi this_month otomatis berganti key saat bulan berganti)
+func (f TransactionFilter) cacheKeyPart() string {
+	t := func(v *time.Time) string {
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	n := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	}
+	return strings.Join([]string{f.Type, f.Category, f.Account, f.Payee, f.Status, f.Description,
+		n(f.MinAmount), n(f.MaxAmount), t(f.From), t(f.To)}, "|")
+}
+
+// serveCached melayani response JSON endpoint dashboard dari cache. Key terdiri dari endpoint,
+// pemilik (user / workspace), filter dan versi bulan-bulan months (biasanya cacheMonths(filter),
+// nil untuk semua bulan), sehingga entry otomatis basi saat transaksi di bulan itu berubah.
+// ETag diambil dari key yang sama, jadi If-None-Match yang cocok langsung dibalas 304 tanpa
+// menghitung ulang.
+func serveCached(w http.ResponseWriter, r *http.Request, endpoint string, filter TransactionFilter, months []string, compute func() (interface{}, error)) {
+	writeComputed := func(key, etag string) {
+		v, err := compute()
+		if err != nil {
+			http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+			return
+		}
+		var buf bytes.Buffer
+		json.NewEncoder(&buf).Encode(v)
+		if key != "" {
+			if err := cache.Default.Set(key, buf.Bytes(), cache.TTL); err != nil {
+				log.Printf("simpan cache gagal: %v", err)
+			}
+			w.Header().Set("ETag", etag)
+			w.Header().Set("X-Cache", "MISS")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(buf.Bytes())
+	}
+
+	if cache.Default == nil {
+		writeComputed("", "")
+		return
+	}
+	versions, err := cache.Versions(months)
+	if err != nil {
+		log.Printf("baca versi cache gagal: %v", err)
+		writeComputed("", "")
+		return
+	}
+
+	owner := currentIdentity(r)
+	key := cache.Key(endpoint, owner.UserID, owner.Workspace, filter.cacheKeyPart(), versions)
+	etag := `"` + key[len(key)-32:] + `"`
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if match := r.Header.Get("If-None-Match"); match != "" && (match == "*" || strings.Contains(match, etag)) {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if body, ok, err := cache.Default.Get(key); err == nil && ok {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("X-Cache", "HIT")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+		return
+	}
+	writeComputed(key, etag)
+}
+
+// invalidateDashboardCache dipanggil setelah perubahan transaksi approved ter-commit
+func invalidateDashboardCache(times ...time.Time) {
+	months := make([]string, len(times))
+	for i, t := range times {
+		months[i] = t.In(appZone).Format("2006-01")
+	}
+	cache.InvalidateMonths(months...)
+}
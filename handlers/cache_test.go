@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cash-flow-go/cache"
+)
+
+func dashboardETag(t *testing.T, url string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("X-User-ID", "budi")
+	req.Header.Set("If-None-Match", "*")
+	rec := httptest.NewRecorder()
+	GetDashboard(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("GetDashboard status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+	return rec.Header().Get("ETag")
+}
+
+func TestDashboardCacheDependsOnEarlierMonths(t *testing.T) {
+	prev := cache.Default
+	cache.Default = cache.NewMemory(100)
+	defer func() { cache.Default = prev }()
+
+	const url = "/api/dashboard?from=2025-03-01&to=2025-03-31"
+	before := dashboardETag(t, url)
+	if before == "" {
+		t.Fatal("GetDashboard returned no ETag")
+	}
+	if again := dashboardETag(t, url); again != before {
+		t.Fatalf("ETag changed without invalidation: %s -> %s", before, again)
+	}
+
+	// Transaksi Januari mengubah saldo awal Maret
+	invalidateDashboardCache(time.Date(2025, 1, 15, 12, 0, 0, 0, appZone))
+	if after := dashboardETag(t, url); after == before {
+		t.Errorf("ETag %s still valid after a change before from", before)
+	}
+}
+
+func TestBarCacheScopedToRange(t *testing.T) {
+	prev := cache.Default
+	cache.Default = cache.NewMemory(100)
+	defer func() { cache.Default = prev }()
+
+	etag := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/api/dashboard/bar?from=2025-03-01&to=2025-03-31", nil)
+		req.Header.Set("If-None-Match", "*")
+		rec := httptest.NewRecorder()
+		GetBarChart(rec, req)
+		return rec.Header().Get("ETag")
+	}
+
+	before := etag()
+	invalidateDashboardCache(time.Date(2025, 1, 15, 12, 0, 0, 0, appZone))
+	if after := etag(); after != before {
+		t.Errorf("ETag changed after a change outside the range: %s -> %s", before, after)
+	}
+	invalidateDashboardCache(time.Date(2025, 3, 15, 12, 0, 0, 0, appZone))
+	if after := etag(); after == before {
+		t.Errorf("ETag %s still valid after a change inside the range", before)
+	}
+}
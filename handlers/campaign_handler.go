@@ -1,46 +1,351 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
+	"mime/multipart"
 	"net/http"
-	"os"
+	"net/url"
 	"path/filepath"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
-)
 
-var (
-	campaigns  = []Campaign{}
-	idCounter  = 1
-	campaignMu sync.Mutex
+	db "cash-flow-go/database"
+	"cash-flow-go/events"
+	"cash-flow-go/imageproc"
+	"cash-flow-go/models"
+	"cash-flow-go/storage"
+
+	"github.com/gorilla/mux"
 )
 
-type Campaign struct {
-	ID       int       `json:"id"`
-	ImageURL string    `json:"image_url"`
-	IsActive bool      `json:"-"`
-	StartAt  time.Time `json:"start_at"`
-	EndAt    time.Time `json:"end_at"`
+// Format waktu lokal yang diterima jika start_at / end_at dikirim tanpa offset.
+// Waktu seperti ini dibaca dalam timezone campaign, bukan UTC.
+var campaignTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05"}
+
+// presentCampaign melengkapi field view-only (signed URL gambar & varian, dan status)
+func presentCampaign(ctx context.Context, c *models.Campaign, now time.Time) error {
+	imageURL, err := storage.Store.SignedURL(ctx, c.ImageKey, storage.URLTTL())
+	if err != nil {
+		return err
+	}
+	c.ImageURL = imageURL
+
+	c.Variants = map[string]string{}
+	for name, key := range c.VariantKeys() {
+		if c.Variants[name], err = storage.Store.SignedURL(ctx, key, storage.URLTTL()); err != nil {
+			return err
+		}
+	}
+	c.Status = c.StatusAt(now)
+
+	// Simpan UTC, tampilkan dalam zona waktu campaign
+	loc := c.Location()
+	c.StartAt, c.EndAt = c.StartAt.In(loc), c.EndAt.In(loc)
+	return nil
+}
+
+func writeCampaign(w http.ResponseWriter, r *http.Request, c *models.Campaign) {
+	if err := presentCampaign(r.Context(), c, time.Now()); err != nil {
+		http.Error(w, "Failed to sign image URL", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+func campaignID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID tidak valid", http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func writeCampaignError(w http.ResponseWriter, err error) {
+	if errors.Is(err, db.ErrCampaignNotFound) {
+		http.Error(w, "Campaign tidak ditemukan", http.StatusNotFound)
+		return
+	}
+	http.Error(w, "Failed to save campaign", http.StatusInternalServerError)
+}
+
+// parseCampaignTime menerima RFC3339 dengan offset (2025-08-07T08:00:00+07:00)
+// atau waktu lokal tanpa offset yang dibaca di loc. Hasil selalu UTC.
+func parseCampaignTime(v string, loc *time.Location) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC(), true
+	}
+	for _, layout := range campaignTimeLayouts {
+		if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseCampaignSchedule membaca timezone, start_at / end_at dan jadwal berulang dari form.
+// Field yang tidak dikirim tetap memakai nilai campaign (dipakai saat update sebagian).
+func parseCampaignSchedule(r *http.Request, c *models.Campaign) error {
+	if _, ok := r.Form["timezone"]; ok || c.Timezone == "" {
+		tz := r.FormValue("timezone")
+		if tz == "" {
+			tz = models.DefaultCampaignTimezone
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			return errors.New("timezone harus nama zona IANA, misal Asia/Jakarta")
+		}
+		c.Timezone = tz
+	}
+	loc := c.Location()
+
+	if v := r.FormValue("start_at"); v != "" {
+		t, ok := parseCampaignTime(v, loc)
+		if !ok {
+			return errors.New("Invalid start_at format (use YYYY-MM-DDTHH:MM:SS or RFC3339 with offset)")
+		}
+		c.StartAt = t
+	}
+	if v := r.FormValue("end_at"); v != "" {
+		t, ok := parseCampaignTime(v, loc)
+		if !ok {
+			return errors.New("Invalid end_at format (use YYYY-MM-DDTHH:MM:SS or RFC3339 with offset)")
+		}
+		c.EndAt = t
+	}
+	if c.StartAt.IsZero() || c.EndAt.IsZero() {
+		return errors.New("Start and end time are required")
+	}
+	if c.EndAt.Before(c.StartAt) {
+		return errors.New("end_at harus setelah start_at")
+	}
+
+	if _, ok := r.Form["recurrence_days"]; ok {
+		c.RecurrenceDays = splitFormList(r.FormValue("recurrence_days"), true)
+		for _, day := range c.RecurrenceDays {
+			if !models.IsValidWeekdayCode(day) {
+				return errors.New("recurrence_days harus berisi sun, mon, tue, wed, thu, fri atau sat")
+			}
+		}
+	}
+	if _, ok := r.Form["recurrence_start"]; ok {
+		c.RecurrenceStart = r.FormValue("recurrence_start")
+	}
+	if _, ok := r.Form["recurrence_end"]; ok {
+		c.RecurrenceEnd = r.FormValue("recurrence_end")
+	}
+
+	if len(c.RecurrenceDays) == 0 {
+		c.RecurrenceStart, c.RecurrenceEnd = "", ""
+		return nil
+	}
+	if !models.ParseClock(c.RecurrenceStart) || !models.ParseClock(c.RecurrenceEnd) {
+		return errors.New("recurrence_start dan recurrence_end wajib format HH:MM")
+	}
+	if c.RecurrenceStart == c.RecurrenceEnd {
+		return errors.New("recurrence_start dan recurrence_end tidak boleh sama")
+	}
+	return nil
+}
+
+// parseCampaignPlacement membaca placement, prioritas, bobot dan targeting dari form.
+// Hanya field yang dikirim yang diubah, sehingga bisa dipakai untuk create maupun update.
+func parseCampaignPlacement(r *http.Request, c *models.Campaign) error {
+	if _, ok := r.Form["placement"]; ok {
+		c.Placement = r.FormValue("placement")
+	}
+	if c.Placement == "" {
+		c.Placement = models.PlacementDashboardTop
+	}
+	if !models.IsValidPlacement(c.Placement) {
+		return fmt.Errorf("placement harus salah satu dari: %s", strings.Join(models.Placements, ", "))
+	}
+
+	if v := r.FormValue("priority"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("priority harus berupa angka")
+		}
+		c.Priority = p
+	}
+
+	if v := r.FormValue("weight"); v != "" {
+		wt, err := strconv.Atoi(v)
+		if err != nil || wt < 1 {
+			return errors.New("weight minimal 1")
+		}
+		c.Weight = wt
+	}
+	if c.Weight < 1 {
+		c.Weight = 1
+	}
+
+	if _, ok := r.Form["target_roles"]; ok {
+		c.TargetRoles = splitFormList(r.FormValue("target_roles"), true)
+	}
+	if _, ok := r.Form["target_workspaces"]; ok {
+		c.TargetWorkspaces = splitFormList(r.FormValue("target_workspaces"), false)
+	}
+	return nil
+}
+
+// parseCampaignClickURL membaca click_url (tujuan redirect saat banner diklik)
+func parseCampaignClickURL(r *http.Request, c *models.Campaign) error {
+	if _, ok := r.Form["click_url"]; !ok {
+		return nil
+	}
+	v := strings.TrimSpace(r.FormValue("click_url"))
+	if v != "" {
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("click_url harus URL http/https yang valid")
+		}
+	}
+	c.ClickURL = v
+	return nil
+}
+
+// splitFormList memecah nilai form yang dipisah koma, misal "manager,member"
+func splitFormList(v string, lower bool) []string {
+	list := []string{}
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if lower {
+			item = strings.ToLower(item)
+		}
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// pickCampaign memilih satu campaign: ambil grup prioritas tertinggi,
+// lalu acak berbobot supaya campaign yang overlap tayang bergantian.
+func pickCampaign(candidates []models.Campaign) *models.Campaign {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	top := candidates[0].Priority
+	total := 0
+	var group []models.Campaign
+	for _, c := range candidates {
+		if c.Priority > top {
+			top, total, group = c.Priority, 0, nil
+		}
+		if c.Priority == top {
+			group = append(group, c)
+			total += max(c.Weight, 1)
+		}
+	}
+
+	n := rand.Intn(total)
+	for i := range group {
+		n -= max(group[i].Weight, 1)
+		if n < 0 {
+			return &group[i]
+		}
+	}
+	return &group[len(group)-1]
+}
+
+const maxCampaignImageSize = 10 << 20
+
+// campaignImage adalah hasil upload gambar campaign (asli + varian) di storage
+type campaignImage struct {
+	key, thumbnail, mobile, desktop string
+	width, height                   int
+}
+
+func (img campaignImage) applyTo(c *models.Campaign) {
+	c.ImageKey, c.ThumbnailKey, c.MobileKey, c.DesktopKey = img.key, img.thumbnail, img.mobile, img.desktop
+	c.ImageWidth, c.ImageHeight = img.width, img.height
+}
+
+func deleteImageKeys(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if key != "" {
+			storage.Store.Delete(ctx, key)
+		}
+	}
+}
+
+// saveCampaignImage memvalidasi gambar (JPEG/PNG/WebP asli, dimensi maksimal),
+// membuang EXIF, membuat varian responsif, lalu mengunggah semuanya ke storage.
+// Mengembalikan status HTTP yang sesuai jika gagal.
+func saveCampaignImage(ctx context.Context, file multipart.File, handler *multipart.FileHeader) (campaignImage, int, error) {
+	if handler.Size > maxCampaignImageSize {
+		return campaignImage{}, http.StatusRequestEntityTooLarge, errors.New("Ukuran gambar maksimal 10MB")
+	}
+
+	processed, err := imageproc.Process(io.LimitReader(file, maxCampaignImageSize+1))
+	if errors.Is(err, imageproc.ErrUnsupportedType) {
+		return campaignImage{}, http.StatusUnsupportedMediaType, err
+	}
+	if err != nil {
+		return campaignImage{}, http.StatusBadRequest, err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(handler.Filename), filepath.Ext(handler.Filename))
+	prefix := fmt.Sprintf("campaigns/%d_%s", time.Now().Unix(), base)
+
+	img := campaignImage{
+		width:  processed.Original.Width,
+		height: processed.Original.Height,
+	}
+	put := func(suffix string, enc imageproc.Encoded) (string, error) {
+		key := prefix + suffix + enc.Ext
+		return key, storage.Store.Put(ctx, key, bytes.NewReader(enc.Data), int64(len(enc.Data)), enc.ContentType)
+	}
+
+	if img.key, err = put("", processed.Original); err == nil {
+		if img.thumbnail, err = put("_thumbnail", processed.Variants["thumbnail"]); err == nil {
+			if img.mobile, err = put("_mobile", processed.Variants["mobile"]); err == nil {
+				img.desktop, err = put("_desktop", processed.Variants["desktop"])
+			}
+		}
+	}
+	if err != nil {
+		deleteImageKeys(ctx, []string{img.key, img.thumbnail, img.mobile, img.desktop})
+		return campaignImage{}, http.StatusInternalServerError, errors.New("Failed to save file")
+	}
+	return img, http.StatusOK, nil
 }
 
 // CreateCampaign godoc
 // @Summary Upload campaign baru dengan waktu aktif
-// @Description Mengunggah campaign (dengan waktu mulai & akhir) dan menjadikannya aktif.
+// @Description Mengunggah campaign (dengan waktu mulai & akhir). Campaign lain tidak dinonaktifkan, sehingga beberapa campaign bisa dijadwalkan antre.
 // @Tags Campaign
 // @Accept multipart/form-data
 // @Produce json
-// @Param image formData file true "Gambar campaign"
-// @Param start_at formData string true "Waktu mulai campaign (format: 2006-01-02T15:04:05)"
-// @Param end_at formData string true "Waktu akhir campaign (format: 2006-01-02T15:04:05)"
-// @Success 200 {object} map[string]string
+// @Param image formData file true "Gambar campaign (JPEG/PNG/WebP, maks 10MB dan 4096x4096)"
+// @Param start_at formData string true "Waktu mulai campaign (2025-08-07T08:00:00+07:00, atau 2025-08-07T08:00:00 dalam timezone)"
+// @Param end_at formData string true "Waktu akhir campaign (2025-08-14T08:00:00+07:00, atau 2025-08-14T08:00:00 dalam timezone)"
+// @Param timezone formData string false "Zona waktu IANA campaign (default Asia/Jakarta)"
+// @Param recurrence_days formData string false "Hari tayang berulang, dipisah koma (sun,mon,tue,wed,thu,fri,sat)"
+// @Param recurrence_start formData string false "Jam mulai slot berulang (HH:MM)"
+// @Param recurrence_end formData string false "Jam akhir slot berulang (HH:MM)"
+// @Param placement formData string false "Placement (dashboard-top/transaction-list/splash, default dashboard-top)"
+// @Param priority formData int false "Prioritas, angka lebih besar menang (default 0)"
+// @Param weight formData int false "Bobot rotasi antar campaign dengan prioritas sama (default 1)"
+// @Param target_roles formData string false "Role target, dipisah koma (kosong = semua)"
+// @Param target_workspaces formData string false "Workspace target, dipisah koma (kosong = semua)"
+// @Param click_url formData string false "URL tujuan saat banner diklik"
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/campaigns [post]
 func CreateCampaign(w http.ResponseWriter, r *http.Request) {
-	campaignMu.Lock()
-	defer campaignMu.Unlock()
-
 	err := r.ParseMultipartForm(10 << 20)
 	if err != nil {
 		http.Error(w, "Error parsing form", http.StatusBadRequest)
@@ -54,99 +359,300 @@ func CreateCampaign(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	startStr := r.FormValue("start_at")
-	endStr := r.FormValue("end_at")
-
-	if startStr == "" || endStr == "" {
-		http.Error(w, "Start and end time are required", http.StatusBadRequest)
+	newCampaign := models.Campaign{IsActive: true}
+	if err := parseCampaignSchedule(r, &newCampaign); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	startAt, err := time.Parse("2006-01-02T15:04:05", startStr)
-	if err != nil {
-		http.Error(w, "Invalid start_at format (use YYYY-MM-DDTHH:MM:SS)", http.StatusBadRequest)
+	if err := parseCampaignPlacement(r, &newCampaign); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := parseCampaignClickURL(r, &newCampaign); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	endAt, err := time.Parse("2006-01-02T15:04:05", endStr)
+	img, status, err := saveCampaignImage(r.Context(), file, handler)
 	if err != nil {
-		http.Error(w, "Invalid end_at format (use YYYY-MM-DDTHH:MM:SS)", http.StatusBadRequest)
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	if endAt.Before(startAt) {
-		http.Error(w, "end_at harus setelah start_at", http.StatusBadRequest)
+	img.applyTo(&newCampaign)
+	if err := db.CreateCampaign(&newCampaign); err != nil {
+		deleteImageKeys(r.Context(), newCampaign.ImageKeys())
+		http.Error(w, "Failed to save campaign", http.StatusInternalServerError)
+		return
+	}
+	if err := presentCampaign(r.Context(), &newCampaign, time.Now()); err != nil {
+		http.Error(w, "Failed to sign image URL", http.StatusInternalServerError)
 		return
 	}
 
-	os.MkdirAll("./uploads", os.ModePerm)
-	filename := fmt.Sprintf("%d_%s", time.Now().Unix(), filepath.Base(handler.Filename))
-	filepath := "./uploads/" + filename
+	publish(events.CampaignCreated, currentIdentity(r), newCampaign)
 
-	dst, err := os.Create(filepath)
-	if err != nil {
-		http.Error(w, "Failed to save file", http.StatusInternalServerError)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":  "Campaign created successfully",
+		"campaign": newCampaign,
+	})
+}
+
+// GetActiveCampaign godoc
+// @Summary Ambil campaign yang aktif dan dalam rentang waktu
+// @Description Mendapatkan campaign yang sedang aktif di sebuah placement, sesuai targeting role / workspace user. Jika beberapa overlap, prioritas tertinggi menang lalu dirotasi berdasarkan weight.
+// @Tags Campaign
+// @Produce json
+// @Param placement query string false "Placement (default dashboard-top)"
+// @Param X-User-Role header string false "Role user untuk targeting"
+// @Param X-Workspace-ID header string false "Workspace user untuk targeting"
+// @Success 200 {object} models.Campaign
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/active [get]
+func GetActiveCampaign(w http.ResponseWriter, r *http.Request) {
+	placement := r.URL.Query().Get("placement")
+	if placement == "" {
+		placement = models.PlacementDashboardTop
+	}
+	if !models.IsValidPlacement(placement) {
+		http.Error(w, "Placement tidak valid", http.StatusBadRequest)
 		return
 	}
-	defer dst.Close()
 
-	_, err = dst.ReadFrom(file)
+	identity := currentIdentity(r)
+	candidates, err := db.ActiveCampaigns(placement, identity.Role, identity.Workspace, time.Now())
 	if err != nil {
-		http.Error(w, "Failed to write file", http.StatusInternalServerError)
+		http.Error(w, "Failed to load campaign", http.StatusInternalServerError)
 		return
 	}
-
-	// Nonaktifkan campaign lain
-	for i := range campaigns {
-		campaigns[i].IsActive = false
+	c := pickCampaign(candidates)
+	if c == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "No active campaign",
+		})
+		return
 	}
 
-	host := r.Host
-	if r.TLS != nil {
-		host = "https://" + host
-	} else {
-		host = "http://" + host
+	writeCampaign(w, r, c)
+}
+
+// ListCampaigns godoc
+// @Summary Daftar semua campaign
+// @Description Menampilkan semua campaign beserta statusnya (scheduled, live, expired, disabled), urut berdasarkan start_at
+// @Tags Campaign
+// @Produce json
+// @Param status query string false "Filter status (scheduled/live/expired/disabled)"
+// @Param placement query string false "Filter placement"
+// @Success 200 {array} models.Campaign
+// @Failure 400 {object} map[string]string
+// @Router /api/campaigns [get]
+func ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", models.CampaignScheduled, models.CampaignLive, models.CampaignExpired, models.CampaignDisabled:
+	default:
+		http.Error(w, "Status tidak valid", http.StatusBadRequest)
+		return
 	}
 
-	newCampaign := Campaign{
-		ID:       idCounter,
-		ImageURL: host + "/uploads/" + filename,
-		IsActive: true,
-		StartAt:  startAt,
-		EndAt:    endAt,
+	now := time.Now()
+	campaigns, err := db.ListCampaigns(status, r.URL.Query().Get("placement"), now)
+	if err != nil {
+		http.Error(w, "Failed to load campaign", http.StatusInternalServerError)
+		return
+	}
+	for i := range campaigns {
+		if err := presentCampaign(r.Context(), &campaigns[i], now); err != nil {
+			http.Error(w, "Failed to sign image URL", http.StatusInternalServerError)
+			return
+		}
 	}
-	idCounter++
-	campaigns = append(campaigns, newCampaign)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": "Campaign created successfully",
-	})
+	json.NewEncoder(w).Encode(campaigns)
 }
 
-// GetActiveCampaign godoc
-// @Summary Ambil campaign yang aktif dan dalam rentang waktu
-// @Description Mendapatkan campaign yang sedang aktif berdasarkan waktu saat ini
+// GetCampaign godoc
+// @Summary Detail campaign
 // @Tags Campaign
 // @Produce json
-// @Success 200 {object} handlers.Campaign
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} models.Campaign
 // @Failure 404 {object} map[string]string
-// @Router /api/campaigns/active [get]
-func GetActiveCampaign(w http.ResponseWriter, r *http.Request) {
-	campaignMu.Lock()
-	defer campaignMu.Unlock()
+// @Router /api/campaigns/{id} [get]
+func GetCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	c, err := db.GetCampaign(id)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	writeCampaign(w, r, c)
+}
 
-	now := time.Now()
-	for _, c := range campaigns {
-		if c.IsActive && now.After(c.StartAt) && now.Before(c.EndAt) {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(c)
+// UpdateCampaign godoc
+// @Summary Ubah jadwal, gambar, placement atau targeting campaign
+// @Description Semua field opsional. Jika gambar diganti, file lama dihapus dari storage.
+// @Tags Campaign
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param image formData file false "Gambar campaign baru (JPEG/PNG/WebP, maks 10MB dan 4096x4096)"
+// @Param start_at formData string false "Waktu mulai campaign (RFC3339 dengan offset, atau waktu lokal dalam timezone)"
+// @Param end_at formData string false "Waktu akhir campaign (RFC3339 dengan offset, atau waktu lokal dalam timezone)"
+// @Param timezone formData string false "Zona waktu IANA campaign"
+// @Param recurrence_days formData string false "Hari tayang berulang, dipisah koma (kirim kosong untuk menghapus jadwal berulang)"
+// @Param recurrence_start formData string false "Jam mulai slot berulang (HH:MM)"
+// @Param recurrence_end formData string false "Jam akhir slot berulang (HH:MM)"
+// @Param placement formData string false "Placement (dashboard-top/transaction-list/splash)"
+// @Param priority formData int false "Prioritas"
+// @Param weight formData int false "Bobot rotasi"
+// @Param target_roles formData string false "Role target, dipisah koma (kirim kosong untuk menghapus target)"
+// @Param target_workspaces formData string false "Workspace target, dipisah koma (kirim kosong untuk menghapus target)"
+// @Param click_url formData string false "URL tujuan saat banner diklik"
+// @Success 200 {object} models.Campaign
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id} [put]
+func UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	// Upload gambar baru dulu (di luar transaksi DB), key lama dihapus setelah commit
+	var newImage *campaignImage
+	if file, handler, err := r.FormFile("image"); err == nil {
+		defer file.Close()
+		img, status, err := saveCampaignImage(r.Context(), file, handler)
+		if err != nil {
+			http.Error(w, err.Error(), status)
 			return
 		}
+		newImage = &img
 	}
 
-	w.WriteHeader(http.StatusNotFound)
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": "No active campaign",
+	var oldKeys []string
+	var validationErr error
+	c, err := db.UpdateCampaign(id, func(c *models.Campaign) error {
+		if err := parseCampaignSchedule(r, c); err != nil {
+			validationErr = err
+			return err
+		}
+		if err := parseCampaignPlacement(r, c); err != nil {
+			validationErr = err
+			return err
+		}
+		if err := parseCampaignClickURL(r, c); err != nil {
+			validationErr = err
+			return err
+		}
+		if newImage != nil {
+			oldKeys = c.ImageKeys()
+			newImage.applyTo(c)
+		}
+		return nil
 	})
+	if err != nil {
+		if newImage != nil {
+			deleteImageKeys(r.Context(), []string{newImage.key, newImage.thumbnail, newImage.mobile, newImage.desktop})
+		}
+		if validationErr != nil {
+			http.Error(w, validationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		writeCampaignError(w, err)
+		return
+	}
+	deleteImageKeys(r.Context(), oldKeys)
+
+	writeCampaign(w, r, c)
+	publish(events.CampaignUpdated, currentIdentity(r), c)
+}
+
+// DeleteCampaign godoc
+// @Summary Hapus campaign
+// @Description Menghapus campaign beserta file gambarnya
+// @Tags Campaign
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id} [delete]
+func DeleteCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	c, err := db.DeleteCampaign(id)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	publish(events.CampaignDeleted, currentIdentity(r), map[string]interface{}{"id": c.ID})
+	for _, key := range c.ImageKeys() {
+		if err := storage.Store.Delete(r.Context(), key); err != nil {
+			http.Error(w, "Campaign dihapus, tapi gagal menghapus file gambar", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Campaign berhasil dihapus"})
+}
+
+// ActivateCampaign godoc
+// @Summary Aktifkan campaign
+// @Description Campaign aktif akan tayang otomatis saat start_at tiba
+// @Tags Campaign
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} models.Campaign
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id}/activate [post]
+func ActivateCampaign(w http.ResponseWriter, r *http.Request) {
+	setCampaignActive(w, r, true)
+}
+
+// DeactivateCampaign godoc
+// @Summary Nonaktifkan campaign
+// @Description Campaign nonaktif tidak akan tayang meskipun masih dalam rentang waktu
+// @Tags Campaign
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} models.Campaign
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id}/deactivate [post]
+func DeactivateCampaign(w http.ResponseWriter, r *http.Request) {
+	setCampaignActive(w, r, false)
+}
+
+func setCampaignActive(w http.ResponseWriter, r *http.Request, active bool) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	c, err := db.UpdateCampaign(id, func(c *models.Campaign) error {
+		c.IsActive = active
+		return nil
+	})
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	writeCampaign(w, r, c)
+
+	eventType := events.CampaignDeactivated
+	if active {
+		eventType = events.CampaignActivated
+	}
+	publish(eventType, currentIdentity(r), c)
 }
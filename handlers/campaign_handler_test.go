@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"cash-flow-go/models"
+
+	"github.com/lib/pq"
+)
+
+func scheduleRequest(t *testing.T, form url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/campaigns", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := r.ParseForm(); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestParseCampaignSchedule(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Jakarta"); err != nil {
+		t.Skip("tzdata tidak tersedia")
+	}
+	utc := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	}
+	existing := models.Campaign{
+		Timezone: "Asia/Jakarta", StartAt: utc(2025, 9, 1, 1, 0), EndAt: utc(2025, 9, 30, 1, 0),
+		RecurrenceDays: pq.StringArray{"fri"}, RecurrenceStart: "17:00", RecurrenceEnd: "21:00",
+	}
+
+	tests := []struct {
+		name     string
+		campaign models.Campaign
+		form     url.Values
+		want     models.Campaign
+		wantErr  string
+	}{
+		{
+			name: "local time read in default zone",
+			form: url.Values{"start_at": {"2025-09-01T08:00:00"}, "end_at": {"2025-09-30 08:00:00"}},
+			want: models.Campaign{Timezone: "Asia/Jakarta", StartAt: utc(2025, 9, 1, 1, 0), EndAt: utc(2025, 9, 30, 1, 0)},
+		},
+		{
+			name: "local time read in given zone",
+			form: url.Values{"timezone": {"America/New_York"}, "start_at": {"2025-09-01T08:00"}, "end_at": {"2025-12-01T08:00"}},
+			want: models.Campaign{Timezone: "America/New_York", StartAt: utc(2025, 9, 1, 12, 0), EndAt: utc(2025, 12, 1, 13, 0)},
+		},
+		{
+			name: "offset wins over zone",
+			form: url.Values{"timezone": {"Asia/Tokyo"}, "start_at": {"2025-09-01T08:00:00+07:00"}, "end_at": {"2025-09-02T00:00:00Z"}},
+			want: models.Campaign{Timezone: "Asia/Tokyo", StartAt: utc(2025, 9, 1, 1, 0), EndAt: utc(2025, 9, 2, 0, 0)},
+		},
+		{
+			name: "recurrence normalised",
+			form: url.Values{"start_at": {"2025-09-01T08:00:00"}, "end_at": {"2025-09-30T08:00:00"},
+				"recurrence_days": {" FRI, sat ,"}, "recurrence_start": {"22:00"}, "recurrence_end": {"02:00"}},
+			want: models.Campaign{Timezone: "Asia/Jakarta", StartAt: utc(2025, 9, 1, 1, 0), EndAt: utc(2025, 9, 30, 1, 0),
+				RecurrenceDays: pq.StringArray{"fri", "sat"}, RecurrenceStart: "22:00", RecurrenceEnd: "02:00"},
+		},
+		{
+			name:     "partial update keeps schedule",
+			campaign: existing,
+			form:     url.Values{"recurrence_end": {"23:00"}},
+			want: models.Campaign{Timezone: "Asia/Jakarta", StartAt: existing.StartAt, EndAt: existing.EndAt,
+				RecurrenceDays: pq.StringArray{"fri"}, RecurrenceStart: "17:00", RecurrenceEnd: "23:00"},
+		},
+		{
+			name:     "clearing days clears hours",
+			campaign: existing,
+			form:     url.Values{"recurrence_days": {""}},
+			want:     models.Campaign{Timezone: "Asia/Jakarta", StartAt: existing.StartAt, EndAt: existing.EndAt, RecurrenceDays: pq.StringArray{}},
+		},
+		{
+			name:    "unknown zone",
+			form:    url.Values{"timezone": {"WIB"}, "start_at": {"2025-09-01T08:00:00"}, "end_at": {"2025-09-30T08:00:00"}},
+			wantErr: "timezone",
+		},
+		{
+			name:    "bad start",
+			form:    url.Values{"start_at": {"01/09/2025"}, "end_at": {"2025-09-30T08:00:00"}},
+			wantErr: "start_at",
+		},
+		{
+			name:    "missing end",
+			form:    url.Values{"start_at": {"2025-09-01T08:00:00"}},
+			wantErr: "required",
+		},
+		{
+			name:    "end before start",
+			form:    url.Values{"start_at": {"2025-09-30T08:00:00"}, "end_at": {"2025-09-01T08:00:00"}},
+			wantErr: "end_at harus setelah start_at",
+		},
+		{
+			name: "unknown day",
+			form: url.Values{"start_at": {"2025-09-01T08:00:00"}, "end_at": {"2025-09-30T08:00:00"},
+				"recurrence_days": {"jumat"}, "recurrence_start": {"17:00"}, "recurrence_end": {"21:00"}},
+			wantErr: "recurrence_days",
+		},
+		{
+			name: "missing hours",
+			form: url.Values{"start_at": {"2025-09-01T08:00:00"}, "end_at": {"2025-09-30T08:00:00"},
+				"recurrence_days": {"fri"}, "recurrence_start": {"17:00"}},
+			wantErr: "HH:MM",
+		},
+		{
+			name:     "empty window",
+			campaign: existing,
+			form:     url.Values{"recurrence_end": {"17:00"}},
+			wantErr:  "tidak boleh sama",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.campaign
+			c.RecurrenceDays = append(pq.StringArray(nil), c.RecurrenceDays...)
+			err := parseCampaignSchedule(scheduleRequest(t, tt.form), &c)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Timezone != tt.want.Timezone || !c.StartAt.Equal(tt.want.StartAt) || !c.EndAt.Equal(tt.want.EndAt) {
+				t.Errorf("zone/start/end = %s/%s/%s, want %s/%s/%s", c.Timezone, c.StartAt, c.EndAt,
+					tt.want.Timezone, tt.want.StartAt, tt.want.EndAt)
+			}
+			if strings.Join(c.RecurrenceDays, ",") != strings.Join(tt.want.RecurrenceDays, ",") ||
+				c.RecurrenceStart != tt.want.RecurrenceStart || c.RecurrenceEnd != tt.want.RecurrenceEnd {
+				t.Errorf("recurrence = %v %s-%s, want %v %s-%s", c.RecurrenceDays, c.RecurrenceStart, c.RecurrenceEnd,
+					tt.want.RecurrenceDays, tt.want.RecurrenceStart, tt.want.RecurrenceEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+)
+
+const sessionCookie = "cf_sid"
+
+// GIF transparan 1x1 untuk beacon <img>
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// viewerKey mengidentifikasi penonton untuk dedup: user ID jika login,
+// selain itu cookie sesi (dibuat jika belum ada).
+func viewerKey(w http.ResponseWriter, r *http.Request) string {
+	if id := currentIdentity(r).UserID; id != "" {
+		return "user:" + id
+	}
+	if c, err := r.Cookie(sessionCookie); err == nil && c.Value != "" {
+		return "session:" + c.Value
+	}
+
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	sid := hex.EncodeToString(buf)
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    sid,
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return "session:" + sid
+}
+
+func ctr(clicks, impressions int64) float64 {
+	if impressions == 0 {
+		return 0
+	}
+	return float64(clicks) / float64(impressions) * 100
+}
+
+// TrackCampaignImpression godoc
+// @Summary Beacon impression campaign
+// @Description Mencatat impression (dedup per user / sesi per hari). GET mengembalikan GIF 1x1 untuk dipakai sebagai <img>, POST mengembalikan 204.
+// @Tags Campaign Analytics
+// @Produce image/gif
+// @Param id path int true "Campaign ID"
+// @Success 200 {file} file
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id}/impression [get]
+// @Router /api/campaigns/{id}/impression [post]
+func TrackCampaignImpression(w http.ResponseWriter, r *http.Request) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := db.GetCampaign(id); err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	if _, err := db.RecordCampaignEvent(id, dayOf(time.Now()), models.CampaignEventImpression, viewerKey(w, r)); err != nil {
+		http.Error(w, "Gagal mencatat impression", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "image/gif")
+	w.Write(transparentGIF)
+}
+
+// TrackCampaignClick godoc
+// @Summary Redirect klik campaign
+// @Description Mencatat klik (dedup per user / sesi per hari) lalu redirect ke click_url campaign
+// @Tags Campaign Analytics
+// @Param id path int true "Campaign ID"
+// @Success 302
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id}/click [get]
+func TrackCampaignClick(w http.ResponseWriter, r *http.Request) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	c, err := db.GetCampaign(id)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	if c.ClickURL == "" {
+		http.Error(w, "Campaign tidak punya click_url", http.StatusNotFound)
+		return
+	}
+
+	// Gagal mencatat klik tidak boleh menghalangi user sampai ke tujuan
+	db.RecordCampaignEvent(id, dayOf(time.Now()), models.CampaignEventClick, viewerKey(w, r))
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, c.ClickURL, http.StatusFound)
+}
+
+// GetCampaignStats godoc
+// @Summary Statistik campaign
+// @Description Total impression, klik dan CTR (%) campaign selama StartAt - EndAt, beserta rincian harian
+// @Tags Campaign Analytics
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} models.CampaignStatsResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id}/stats [get]
+func GetCampaignStats(w http.ResponseWriter, r *http.Request) {
+	id, ok := campaignID(w, r)
+	if !ok {
+		return
+	}
+	c, err := db.GetCampaign(id)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	from, to := dayOf(c.StartAt), dayOf(c.EndAt)
+	if today := dayOf(time.Now()); today.Before(to) {
+		to = today
+	}
+
+	stats, err := db.CampaignDailyStats(c.ID, from, to)
+	if err != nil {
+		http.Error(w, "Gagal mengambil statistik", http.StatusInternalServerError)
+		return
+	}
+
+	byDay := map[string]models.CampaignDailyStat{}
+	for _, s := range stats {
+		byDay[s.Day.Format("2006-01-02")] = s
+	}
+
+	resp := models.CampaignStatsResponse{
+		CampaignID: c.ID,
+		From:       from.Format("2006-01-02"),
+		To:         to.Format("2006-01-02"),
+		Daily:      []models.CampaignDayStat{},
+	}
+	// Isi semua hari di window, termasuk hari tanpa event
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		s := byDay[key]
+		resp.Impressions += s.Impressions
+		resp.Clicks += s.Clicks
+		resp.Daily = append(resp.Daily, models.CampaignDayStat{
+			Date:        key,
+			Impressions: s.Impressions,
+			Clicks:      s.Clicks,
+			CTR:         ctr(s.Clicks, s.Impressions),
+		})
+	}
+	resp.CTR = ctr(resp.Clicks, resp.Impressions)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+)
+
+const topMoversLimit = 5
+
+// Pilihan periode pembanding
+const (
+	ComparePrevious = "previous"
+	CompareLastYear = "last_year"
+)
+
+// comparisonRanges menentukan periode sekarang dari filter (default bulan ini) dan periode
+// pembanding dari compare (previous / last_year) atau compare_from / compare_to.
+func comparisonRanges(r *http.Request, filter TransactionFilter) (cur, prev [2]time.Time, err error) {
+	now := time.Now().In(appZone)
+	switch {
+	case filter.From == nil && filter.To == nil:
+		cur[0] = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, appZone)
+		cur[1] = cur[0].AddDate(0, 1, 0)
+	case filter.From == nil:
+		return cur, prev, errors.New("from wajib diisi untuk perbandingan")
+	case filter.To == nil:
+		cur[0] = *filter.From
+		cur[1] = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, appZone)
+	default:
+		cur[0], cur[1] = *filter.From, *filter.To
+	}
+
+	q := r.URL.Query()
+	if q.Get("compare_from") != "" || q.Get("compare_to") != "" {
+		if prev[0], err = parseFilterTime(q.Get("compare_from"), false); err != nil {
+			return cur, prev, errors.New("compare_from harus format YYYY-MM-DD atau RFC3339")
+		}
+		if prev[1], err = parseFilterTime(q.Get("compare_to"), true); err != nil {
+			return cur, prev, errors.New("compare_to harus format YYYY-MM-DD atau RFC3339")
+		}
+		if !prev[1].After(prev[0]) {
+			return cur, prev, errors.New("compare_to harus setelah compare_from")
+		}
+		return cur, prev, nil
+	}
+
+	switch q.Get("compare") {
+	case "", ComparePrevious:
+		prev = previousRange(cur)
+	case CompareLastYear:
+		prev = [2]time.Time{cur[0].AddDate(-1, 0, 0), cur[1].AddDate(-1, 0, 0)}
+	default:
+		return cur, prev, errors.New("compare harus previous atau last_year")
+	}
+	return cur, prev, nil
+}
+
+// previousRange mengembalikan periode tepat sebelum rng dengan panjang yang sama.
+// Rentang yang pas bulan kalender digeser per bulan (Maret dibanding Februari, bukan 31 hari).
+func previousRange(rng [2]time.Time) [2]time.Time {
+	from, to := rng[0].In(appZone), rng[1].In(appZone)
+	isMonthStart := func(t time.Time) bool {
+		return t.Day() == 1 && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+	}
+	if isMonthStart(from) && isMonthStart(to) {
+		months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+		return [2]time.Time{from.AddDate(0, -months, 0), from}
+	}
+	return [2]time.Time{from.Add(-to.Sub(from)), from}
+}
+
+func formatRange(rng [2]time.Time) models.PeriodRange {
+	return models.PeriodRange{
+		From: rng[0].In(appZone).Format("2006-01-02"),
+		To:   rng[1].Add(-time.Nanosecond).In(appZone).Format("2006-01-02"),
+	}
+}
+
+type categoryKey struct{ Type, Category string }
+
+// periodTotals menjumlahkan pemasukan, pengeluaran dan per kategori dalam satu periode
+func periodTotals(filter TransactionFilter, rng [2]time.Time) (map[string]float64, map[categoryKey]float64, error) {
+	filter.From, filter.To = &rng[0], &rng[1]
+
+	var totals []struct {
+		Type  string
+		Total float64
+	}
+	err := filter.Apply(db.DB.Model(&models.Transaction{})).
+		Select("transactions.type AS type, SUM(amount) AS total").
+		Group("type").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	categorySQL, _ := breakdownKeySQL(BreakdownCategory)
+	var rows []struct {
+		Type     string
+		Category string
+		Total    float64
+	}
+	err = filter.Apply(db.DB.Model(&models.Transaction{})).
+		Select("transactions.type AS type, " + categorySQL + " AS category, SUM(amount) AS total").
+		Group("type, category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byType := map[string]float64{}
+	for _, t := range totals {
+		byType[t.Type] = t.Total
+	}
+	byCategory := map[categoryKey]float64{}
+	for _, r := range rows {
+		byCategory[categoryKey{r.Type, r.Category}] = r.Total
+	}
+	return byType, byCategory, nil
+}
+
+// GetComparison godoc
+// @Summary Perbandingan dua periode
+// @Description Delta total dan per kategori (absolut dan persen) antara periode sekarang (filter period / from / to, default bulan ini) dan periode pembanding. Pembanding default adalah periode sebelumnya dengan panjang sama (bulan kalender digeser per bulan), atau last_year, atau compare_from / compare_to. top_movers berisi kategori dengan perubahan absolut terbesar.
+// @Tags Statistik
+// @Produce json
+// @Param period query string false "Preset periode sekarang (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param compare query string false "previous (default) atau last_year"
+// @Param compare_from query string false "Tanggal mulai periode pembanding"
+// @Param compare_to query string false "Tanggal akhir periode pembanding, inklusif"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
+// @Success 200 {object} models.ComparisonResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/dashboard/compare [get]
+func GetComparison(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter = filter.ForDashboard("")
+
+	cur, prev, err := comparisonRanges(r, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	curTotals, curCategories, err := periodTotals(filter, cur)
+	if err != nil {
+		http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+		return
+	}
+	prevTotals, prevCategories, err := periodTotals(filter, prev)
+	if err != nil {
+		http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.ComparisonResponse{
+		Current:  formatRange(cur),
+		Previous: formatRange(prev),
+		Income:   models.NewDelta(curTotals["pemasukan"], prevTotals["pemasukan"]),
+		Expense:  models.NewDelta(curTotals["pengeluaran"], prevTotals["pengeluaran"]),
+		Net: models.NewDelta(curTotals["pemasukan"]-curTotals["pengeluaran"],
+			prevTotals["pemasukan"]-prevTotals["pengeluaran"]),
+		Categories: []models.CategoryDelta{},
+	}
+
+	keys := map[categoryKey]bool{}
+	for k := range curCategories {
+		keys[k] = true
+	}
+	for k := range prevCategories {
+		keys[k] = true
+	}
+	for k := range keys {
+		resp.Categories = append(resp.Categories, models.CategoryDelta{
+			Category: k.Category,
+			Type:     k.Type,
+			Delta:    models.NewDelta(curCategories[k], prevCategories[k]),
+		})
+	}
+	sort.Slice(resp.Categories, func(i, j int) bool {
+		a, b := resp.Categories[i], resp.Categories[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Category < b.Category
+	})
+
+	resp.TopMovers = append([]models.CategoryDelta{}, resp.Categories...)
+	sort.SliceStable(resp.TopMovers, func(i, j int) bool {
+		return math.Abs(resp.TopMovers[i].Change) > math.Abs(resp.TopMovers[j].Change)
+	})
+	resp.TopMovers = resp.TopMovers[:min(len(resp.TopMovers), topMoversLimit)]
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetYearOverYear godoc
+// @Summary Perbandingan bulan yang sama antar tahun
+// @Description Pemasukan, pengeluaran dan net satu bulan kalender (default bulan ini) selama beberapa tahun terakhir, beserta perubahan persen dibanding tahun sebelumnya
+// @Tags Statistik
+// @Produce json
+// @Param month query int false "Bulan 1-12 (default bulan ini)"
+// @Param years query int false "Jumlah tahun (default 5, maksimal 20)"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
+// @Success 200 {object} models.YoYResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/dashboard/yoy [get]
+func GetYearOverYear(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter = filter.ForDashboard("")
+	filter.Type = ""
+	filter.From, filter.To = nil, nil
+
+	now := time.Now().In(appZone)
+	month := int(now.Month())
+	if v := r.URL.Query().Get("month"); v != "" {
+		if month, err = strconv.Atoi(v); err != nil || month < 1 || month > 12 {
+			http.Error(w, "month harus 1-12", http.StatusBadRequest)
+			return
+		}
+	}
+	years := 5
+	if v := r.URL.Query().Get("years"); v != "" {
+		if years, err = strconv.Atoi(v); err != nil || years < 1 || years > 20 {
+			http.Error(w, "years harus 1-20", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Tahun terakhir adalah tahun terakhir yang bulan tersebut sudah berjalan
+	lastYear := now.Year()
+	if month > int(now.Month()) {
+		lastYear--
+	}
+	firstYear := lastYear - years + 1
+	from := time.Date(firstYear, time.Month(month), 1, 0, 0, 0, 0, appZone)
+	filter.From = &from
+
+	var rows []struct {
+		Year    int
+		Income  float64
+		Expense float64
+	}
+	err = filter.Apply(db.DB.Model(&models.Transaction{})).
+		Select(`EXTRACT(YEAR FROM `+localTimeSQL()+`)::int AS year,
+			COALESCE(SUM(amount) FILTER (WHERE type = 'pemasukan'), 0) AS income,
+			COALESCE(SUM(amount) FILTER (WHERE type = 'pengeluaran'), 0) AS expense`).
+		Where("EXTRACT(MONTH FROM "+localTimeSQL()+") = ?", month).
+		Group("year").
+		Scan(&rows).Error
+	if err != nil {
+		http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+		return
+	}
+
+	byYear := map[int]models.YoYYear{}
+	for _, row := range rows {
+		byYear[row.Year] = models.YoYYear{Income: row.Income, Expense: row.Expense}
+	}
+
+	resp := models.YoYResponse{Month: time.Month(month).String(), Years: []models.YoYYear{}}
+	for y := firstYear; y <= lastYear; y++ {
+		cur := byYear[y]
+		cur.Year = y
+		cur.Net = cur.Income - cur.Expense
+		if y > firstYear {
+			prev := byYear[y-1]
+			cur.IncomeChangePercent = models.NewDelta(cur.Income, prev.Income).ChangePercent
+			cur.ExpenseChangePercent = models.NewDelta(cur.Expense, prev.Expense).ChangePercent
+		}
+		resp.Years = append(resp.Years, cur)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Ukuran histori yang diuji dan jumlah transaksi per hari
+var (
+	benchYears  = []int{1, 3, 5, 10}
+	benchPerDay = 20
+)
+
+// BenchmarkMonthlyBalances mengukur agregasi GetDashboard terhadap data bertahun-tahun.
+// Butuh Postgres sungguhan; data di-seed ke schema sementara yang dihapus setelah selesai:
+//
+//	BENCH_DB_DSN="host=localhost user=postgres password=postgres dbname=cashflow sslmode=disable" \
+//		go test ./handlers -run '^$' -bench MonthlyBalances -benchtime 20x
+func BenchmarkMonthlyBalances(b *testing.B) {
+	dsn := os.Getenv("BENCH_DB_DSN")
+	if dsn == "" {
+		b.Skip("BENCH_DB_DSN tidak diatur")
+	}
+
+	schema := fmt.Sprintf("dashbench_%d", os.Getpid())
+	conn := benchConnect(b, dsn)
+	if err := conn.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { conn.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	bench := benchConnect(b, dsn+" search_path="+schema)
+	if err := bench.AutoMigrate(&models.Transaction{}, &models.DailySummary{}, &models.DailyCategorySummary{}); err != nil {
+		b.Fatal(err)
+	}
+
+	filter := TransactionFilter{Status: models.StatusApproved}
+	seeded := 0
+	for _, y := range benchYears {
+		// Tambah histori mundur dari tahun yang sudah di-seed
+		if err := benchSeed(bench, seeded, y, benchPerDay); err != nil {
+			b.Fatal(err)
+		}
+		seeded = y
+		if err := db.RebuildDailySummaries(bench, appZone); err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("years=%d/rollup", y), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := MonthlyBalancesFrom(bench, filter, true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("years=%d/grouped", y), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := MonthlyBalancesFrom(bench, filter, false); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("years=%d/per-month", y), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := legacyMonthlyBalances(bench); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchConnect(b *testing.B, dsn string) *gorm.DB {
+	b.Helper()
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		b.Fatal("Gagal konek DB: " + err.Error())
+	}
+	return conn
+}
+
+// benchSeed mengisi transaksi untuk tahun ke-(fromYears+1) sampai ke-toYears ke belakang
+// dari hari ini, langsung di server dengan generate_series supaya cepat.
+func benchSeed(conn *gorm.DB, fromYears, toYears, perDay int) error {
+	now := time.Now()
+	end := now.AddDate(-fromYears, 0, 0)
+	start := now.AddDate(-toYears, 0, 0)
+
+	return conn.Exec(`
+		INSERT INTO transactions (type, amount, description, category, categories, account, status, transaction_at, created_at)
+		SELECT
+			CASE WHEN n % 4 = 0 THEN 'pemasukan' ELSE 'pengeluaran' END,
+			(10000 + (random() * 490000)::int),
+			'seed',
+			(ARRAY['makanan','transport','tagihan','hiburan','gaji'])[1 + n % 5],
+			ARRAY[(ARRAY['makanan','transport','tagihan','hiburan','gaji'])[1 + n % 5]],
+			(ARRAY['bca','mandiri','cash'])[1 + n % 3],
+			'approved',
+			day + (n % 24) * INTERVAL '1 hour',
+			day + (n % 24) * INTERVAL '1 hour'
+		FROM generate_series(?::timestamptz, ?::timestamptz, INTERVAL '1 day') AS day,
+			generate_series(1, ?) AS n
+	`, start, end, perDay).Error
+}
+
+// legacyMonthlyBalances adalah cara lama GetDashboard (2N+3 query) sebagai pembanding
+func legacyMonthlyBalances(conn *gorm.DB) error {
+	var total int64
+	for _, t := range []string{"pemasukan", "pengeluaran"} {
+		if err := conn.Model(&models.Transaction{}).Select("COALESCE(SUM(amount), 0)").
+			Where("type = ? AND status = ?", t, models.StatusApproved).Scan(&total).Error; err != nil {
+			return err
+		}
+	}
+
+	type monthYear struct{ Month, Year int }
+	var monthYears []monthYear
+	if err := conn.Raw(`
+		SELECT DISTINCT EXTRACT(MONTH FROM created_at) AS month, EXTRACT(YEAR FROM created_at) AS year
+		FROM transactions WHERE status = ?
+	`, models.StatusApproved).Scan(&monthYears).Error; err != nil {
+		return err
+	}
+
+	for _, my := range monthYears {
+		for _, t := range []string{"pemasukan", "pengeluaran"} {
+			if err := conn.Model(&models.Transaction{}).Select("COALESCE(SUM(amount), 0)").
+				Where("type = ? AND status = ? AND EXTRACT(MONTH FROM created_at) = ? AND EXTRACT(YEAR FROM created_at) = ?",
+					t, models.StatusApproved, my.Month, my.Year).
+				Scan(&total).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -5,7 +5,10 @@ import (
 	"cash-flow-go/models"
 	"encoding/json"
 	"net/http"
+	"sort"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type MonthlyBalance struct {
@@ -17,98 +20,159 @@ type MonthlyBalance struct {
 	Saldo     int64  `json:"saldo"`
 }
 
-// @Summary Dashboard utama
-// @Description Menampilkan ringkasan transaksi
-// @Tags Dashboard
-// @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Router /api/dashboard [get]
-func GetDashboard(w http.ResponseWriter, r *http.Request) {
-	var pemasukan, pengeluaran int64
-
-	// Total pemasukan dan pengeluaran
-	db.DB.Model(&models.Transaction{}).
-		Select("COALESCE(SUM(amount), 0)").
-		Where("type = ?", "pemasukan").
-		Scan(&pemasukan)
-
-	db.DB.Model(&models.Transaction{}).
-		Select("COALESCE(SUM(amount), 0)").
-		Where("type = ?", "pengeluaran").
-		Scan(&pengeluaran)
-
-	// Ambil semua bulan dan tahun unik dari transaksi
-	type MonthYear struct {
-		Month int
-		Year  int
-	}
-	var monthYears []MonthYear
-	db.DB.Raw(`
-		SELECT DISTINCT 
-			EXTRACT(MONTH FROM created_at) AS month, 
-			EXTRACT(YEAR FROM created_at) AS year
-		FROM transactions
-		ORDER BY EXTRACT(YEAR FROM created_at), EXTRACT(MONTH FROM created_at)
-	`).Scan(&monthYears)
-
-	var monthly []MonthlyBalance
-	var prevSaldo int64 = 0
-
-	for _, my := range monthYears {
-		var income, expense int64
-
-		db.DB.Model(&models.Transaction{}).
-			Select("COALESCE(SUM(amount), 0)").
-			Where("type = ? AND EXTRACT(MONTH FROM created_at) = ? AND EXTRACT(YEAR FROM created_at) = ?", "pemasukan", my.Month, my.Year).
-			Scan(&income)
-
-		db.DB.Model(&models.Transaction{}).
-			Select("COALESCE(SUM(amount), 0)").
-			Where("type = ? AND EXTRACT(MONTH FROM created_at) = ? AND EXTRACT(YEAR FROM created_at) = ?", "pengeluaran", my.Month, my.Year).
-			Scan(&expense)
-
-		saldo := prevSaldo + income - expense
+// MonthlyBalances menghitung pemasukan, pengeluaran dan saldo berjalan per bulan dalam satu query:
+// GROUP BY bulan (zona aplikasi) untuk total bulanan, lalu window function untuk saldo kumulatif.
+// Dibaca dari rollup harian jika filter memungkinkan. Hasil diurutkan dari bulan terbaru.
+func MonthlyBalances(conn *gorm.DB, filter TransactionFilter) ([]MonthlyBalance, error) {
+	return MonthlyBalancesFrom(conn, filter, filter.usesSummaries(false))
+}
 
+// MonthlyBalancesFrom seperti MonthlyBalances tapi sumbernya dipilih pemanggil:
+// rollup daily_summaries atau tabel transactions.
+func MonthlyBalancesFrom(conn *gorm.DB, filter TransactionFilter, fromSummaries bool) ([]MonthlyBalance, error) {
+	rows, err := monthlyBalanceRows(conn, filter, fromSummaries)
+	if err != nil {
+		return nil, err
+	}
+
+	monthly := make([]MonthlyBalance, 0, len(rows))
+	for _, r := range rows {
 		monthly = append(monthly, MonthlyBalance{
-			Month:     time.Month(my.Month).String(),
-			Year:      my.Year,
-			Income:    income,
-			Expense:   expense,
-			PrevSaldo: prevSaldo,
-			Saldo:     saldo,
+			Month:     r.MonthStart.Month().String(),
+			Year:      r.MonthStart.Year(),
+			Income:    r.Income,
+			Expense:   r.Expense,
+			PrevSaldo: r.Saldo - r.Income + r.Expense,
+			Saldo:     r.Saldo,
 		})
+	}
+	return monthly, nil
+}
+
+// monthlyBalanceRow adalah saldo satu bulan; MonthStart tanggal 1 bulan itu di zona aplikasi (terbaca 00:00 UTC)
+type monthlyBalanceRow struct {
+	MonthStart time.Time
+	Income     int64
+	Expense    int64
+	Saldo      int64
+}
 
-		prevSaldo = saldo
+// monthlyBalanceRows menghitung pemasukan, pengeluaran dan saldo berjalan per bulan, terbaru di atas
+func monthlyBalanceRows(conn *gorm.DB, filter TransactionFilter, fromSummaries bool) ([]monthlyBalanceRow, error) {
+	var perMonth *gorm.DB
+	if fromSummaries {
+		perMonth = filter.applySummary(conn.Model(&models.DailySummary{})).
+			Select(`date_trunc('month', day::timestamp) AS month_start,
+				COALESCE(SUM(total) FILTER (WHERE type = 'pemasukan'), 0) AS income,
+				COALESCE(SUM(total) FILTER (WHERE type = 'pengeluaran'), 0) AS expense`).
+			Group("month_start")
+	} else {
+		perMonth = filter.Apply(conn.Model(&models.Transaction{})).
+			Select(periodSQL("month") + ` AS month_start,
+				COALESCE(SUM(amount) FILTER (WHERE type = 'pemasukan'), 0) AS income,
+				COALESCE(SUM(amount) FILTER (WHERE type = 'pengeluaran'), 0) AS expense`).
+			Group("month_start")
 	}
 
-	// Reverse hasil monthly agar tampilannya DESC (terbaru ke terlama)
-	for i, j := 0, len(monthly)-1; i < j; i, j = i+1, j-1 {
-		monthly[i], monthly[j] = monthly[j], monthly[i]
+	// Saldo berjalan dimulai dari saldo sebelum from, bukan dari 0
+	opening, err := openingBalance(conn, filter, fromSummaries)
+	if err != nil {
+		return nil, err
 	}
 
-	// Ambil 3 bulan terakhir
+	var rows []monthlyBalanceRow
+	err = conn.Table("(?) AS m", perMonth).
+		Select("month_start, income, expense, ? + SUM(income - expense) OVER (ORDER BY month_start) AS saldo", opening).
+		Order("month_start DESC").
+		Scan(&rows).Error
+	return rows, err
+}
 
-	last3 := monthly
-	if len(monthly) > 3 {
-		last3 = monthly[:3]
+// openingBalance menghitung saldo (pemasukan - pengeluaran) sebelum filter.From dengan filter
+// lain yang sama. 0 jika filter tidak punya batas awal.
+func openingBalance(conn *gorm.DB, filter TransactionFilter, fromSummaries bool) (int64, error) {
+	if filter.From == nil {
+		return 0, nil
 	}
-
-	response := map[string]interface{}{
-		"total_balance":   pemasukan - pengeluaran,
-		"total_income":    pemasukan,
-		"total_expense":   pengeluaran,
-		"monthly_balance": last3,
+	before := filter
+	before.From, before.To = nil, filter.From
+
+	var q *gorm.DB
+	if fromSummaries {
+		q = before.applySummary(conn.Model(&models.DailySummary{})).
+			Select(`COALESCE(SUM(CASE type WHEN 'pemasukan' THEN total WHEN 'pengeluaran' THEN -total END), 0)`)
+	} else {
+		q = before.Apply(conn.Model(&models.Transaction{})).
+			Select(`COALESCE(SUM(CASE transactions.type WHEN 'pemasukan' THEN amount WHEN 'pengeluaran' THEN -amount END), 0)`)
 	}
+	var opening int64
+	err := q.Scan(&opening).Error
+	return opening, err
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+// @Summary Dashboard utama
+// @Description Menampilkan ringkasan transaksi. Tanpa filter periode hanya 3 bulan terakhir yang dikembalikan di monthly_balance.
+// @Tags Dashboard
+// @Produce json
+// @Param period query string false "Preset periode (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param type query string false "Filter type (pemasukan/pengeluaran)"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/dashboard [get]
+func GetDashboard(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter = filter.ForDashboard("")
+
+	// Saldo dihitung dari saldo awal sebelum From, jadi perubahan di bulan mana pun
+	// sebelum To ikut mengubah response: key bergantung pada semua bulan
+	serveCached(w, r, "dashboard", filter, nil, func() (interface{}, error) {
+		monthly, err := MonthlyBalances(db.DB, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		// Total pemasukan dan pengeluaran = jumlah semua bulan
+		var pemasukan, pengeluaran int64
+		for _, m := range monthly {
+			pemasukan += m.Income
+			pengeluaran += m.Expense
+		}
+
+		// Ambil 3 bulan terakhir, kecuali user memilih periode sendiri
+		last3 := monthly
+		if len(monthly) > 3 && !filter.HasRange() {
+			last3 = monthly[:3]
+		}
+
+		return map[string]interface{}{
+			"total_balance":   pemasukan - pengeluaran,
+			"total_income":    pemasukan,
+			"total_expense":   pengeluaran,
+			"monthly_balance": last3,
+		}, nil
+	})
 }
 
 // GetMonthlyBarChart godoc
-// @Summary Statistik pengeluaran 3 bulan terakhir
-// @Description Menampilkan pengeluaran per kategori tiap bulan (maksimal 3 bulan terakhir)
+// @Summary Statistik pengeluaran per bulan
+// @Description Menampilkan pengeluaran per kategori tiap bulan. Tanpa filter periode, default 3 bulan terakhir.
 // @Tags Statistik
 // @Produce json
+// @Param period query string false "Preset periode (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param type query string false "Filter type (pemasukan/pengeluaran)"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
 // @Success 200 {object} models.ResponseWithMonths
 // @Failure 500 {object} map[string]string
 // @Router /api/dashboard/monthly-bar [get]
@@ -119,92 +183,142 @@ func GetMonthlyBarChart(w http.ResponseWriter, r *http.Request) {
 		Total     float64 `json:"total"`
 	}
 
-	var rows []Row
-
-	err := db.DB.Raw(`
-		SELECT 
-			to_char(date_trunc('month', transaction_at), 'YYYY-MM') AS month,
-			unnest(categories) AS category2,
-			SUM(amount) AS total
-		FROM transactions
-		WHERE 
-			type = 'pengeluaran' AND
-			transaction_at >= NOW() - INTERVAL '3 months'
-		GROUP BY month, category2
-		ORDER BY month ASC
-	`).Scan(&rows).Error
-
+	filter, err := parseTransactionFilter(r)
 	if err != nil {
-		http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	grouped := map[string][]models.MonthlyCategoryItem{}
-
-	for _, r := range rows {
-		grouped[r.Month] = append(grouped[r.Month], models.MonthlyCategoryItem{
-			Category2: r.Category2,
-			Total:     r.Total,
-		})
+	filter = filter.ForDashboard("pengeluaran")
+	if !filter.HasRange() {
+		// Bulan ini dan 2 bulan sebelumnya, sama dengan monthly_balance di GetDashboard
+		now := time.Now().In(appZone)
+		thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, appZone)
+		from, to := thisMonth.AddDate(0, -2, 0), thisMonth.AddDate(0, 1, 0)
+		filter.From, filter.To = &from, &to
 	}
 
-	var result []models.MonthlyCategoryGroup
-	for month, cats := range grouped {
-		result = append(result, models.MonthlyCategoryGroup{
-			Month:      month,
-			Categories: cats,
-		})
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(models.ResponseWithMonths{
-		Months: result,
+	serveCached(w, r, "monthly-bar", filter, cacheMonths(filter), func() (interface{}, error) {
+		var rows []Row
+
+		var q *gorm.DB
+		if filter.usesSummaries(true) {
+			q = filter.applySummary(db.DB.Model(&models.DailyCategorySummary{})).
+				Select(`to_char(date_trunc('month', day::timestamp), 'YYYY-MM') AS month,
+					category AS category2,
+					SUM(total) AS total`)
+		} else {
+			q = filter.Apply(db.DB.Model(&models.Transaction{})).
+				Select(`to_char(` + periodSQL("month") + `, 'YYYY-MM') AS month,
+					unnest(categories) AS category2,
+					SUM(amount) AS total`)
+		}
+		err := q.Group("month, category2").
+			Order("month ASC").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		grouped := map[string][]models.MonthlyCategoryItem{}
+
+		for _, r := range rows {
+			grouped[r.Month] = append(grouped[r.Month], models.MonthlyCategoryItem{
+				Category2: r.Category2,
+				Total:     r.Total,
+			})
+		}
+
+		var result []models.MonthlyCategoryGroup
+		for month, cats := range grouped {
+			result = append(result, models.MonthlyCategoryGroup{
+				Month:      month,
+				Categories: cats,
+			})
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].Month < result[j].Month })
+
+		return models.ResponseWithMonths{Months: result}, nil
 	})
 }
 
 // @Summary Grafik batang pengeluaran
-// @Description Menampilkan grafik batang pengeluaran per kategori
+// @Description Menampilkan grafik batang pengeluaran per kategori (type bisa diganti lewat filter)
 // @Tags Dashboard
 // @Produce json
+// @Param period query string false "Preset periode (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param type query string false "Filter type (pemasukan/pengeluaran)"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
 // @Success 200 {array} map[string]interface{}
 // @Router /api/dashboard/bar [get]
 func GetBarChart(w http.ResponseWriter, r *http.Request) {
 	type Result struct {
 		Category2 string
-		Total     int
+		Total     float64
 	}
 
-	var results []Result
-	db.DB.Raw(`
-		SELECT unnest(categories) AS category2, SUM(amount) AS total
-		FROM transactions
-		WHERE type = 'pengeluaran'
-		GROUP BY category2
-	`).Scan(&results)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(results)
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter = filter.ForDashboard("pengeluaran")
+
+	serveCached(w, r, "bar", filter, cacheMonths(filter), func() (interface{}, error) {
+		var results []Result
+		var q *gorm.DB
+		if filter.usesSummaries(true) {
+			q = filter.applySummary(db.DB.Model(&models.DailyCategorySummary{})).
+				Select("category AS category2, SUM(total) AS total")
+		} else {
+			q = filter.Apply(db.DB.Model(&models.Transaction{})).
+				Select("unnest(categories) AS category2, SUM(amount) AS total")
+		}
+		err := q.Group("category2").Scan(&results).Error
+		return results, err
+	})
 }
 
 // @Summary Grafik donat pemasukan
-// @Description Menampilkan grafik donat pemasukan per kategori
+// @Description Menampilkan grafik donat pemasukan per kategori (type bisa diganti lewat filter)
 // @Tags Dashboard
 // @Produce json
+// @Param period query string false "Preset periode (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param type query string false "Filter type (pemasukan/pengeluaran)"
+// @Param category query string false "Filter category"
+// @Param account query string false "Filter account"
+// @Param payee query string false "Filter payee"
 // @Success 200 {array} map[string]interface{}
 // @Router /api/dashboard/donut [get]
 func GetDonutChart(w http.ResponseWriter, r *http.Request) {
 	type Result struct {
 		Category string
-		Total    int
+		Total    float64
 	}
 
-	var results []Result
-	db.DB.Raw(`
-        SELECT json_each.value AS category, SUM(amount) AS total 
-        FROM transactions, json_each(transactions.categories)
-        WHERE type = 'pemasukan'
-        GROUP BY category
-    `).Scan(&results)
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter = filter.ForDashboard("pemasukan")
 
+	items, err := queryBreakdown(filter, BreakdownCategory)
+	if err != nil {
+		http.Error(w, "Gagal mengambil data", http.StatusInternalServerError)
+		return
+	}
+
+	results := []Result{}
+	for _, it := range items {
+		results = append(results, Result{Category: it.Key, Total: it.Total})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(results)
 }
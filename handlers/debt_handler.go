@@ -0,0 +1,483 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+)
+
+// DebtRequest adalah body untuk membuat hutang piutang. Tanggal memakai format YYYY-MM-DD.
+// transaction_id opsional: transaksi pencairan yang sudah dicatat (piutang: pengeluaran,
+// hutang: pemasukan), supaya tidak terbaca sebagai pengeluaran / pemasukan biasa.
+type DebtRequest struct {
+	Direction     string  `json:"direction" example:"piutang"`
+	Counterparty  string  `json:"counterparty" example:"Andi"`
+	Principal     float64 `json:"principal" example:"2000000"`
+	Description   string  `json:"description" example:"Pinjam buat DP motor"`
+	StartDate     string  `json:"start_date" example:"2025-08-01"`
+	DueDate       string  `json:"due_date,omitempty" example:"2025-10-01"`
+	TransactionID *uint   `json:"transaction_id,omitempty" example:"42"`
+}
+
+// DebtUpdateRequest mengubah data hutang piutang; semua field opsional.
+// status hanya bisa open atau written_off, lunas ditentukan dari pembayaran.
+type DebtUpdateRequest struct {
+	Counterparty *string `json:"counterparty" example:"Andi"`
+	Description  *string `json:"description" example:"Pinjam buat DP motor"`
+	DueDate      *string `json:"due_date" example:"2025-11-01"`
+	Status       *string `json:"status" example:"written_off"`
+}
+
+// RepaymentRequest adalah body untuk mencatat pembayaran. Jika transaction_id diisi dan
+// amount kosong, amount diambil dari transaksi tersebut.
+type RepaymentRequest struct {
+	Amount        float64 `json:"amount" example:"500000"`
+	Date          string  `json:"date,omitempty" example:"2025-08-25"`
+	TransactionID *uint   `json:"transaction_id,omitempty" example:"57"`
+	Note          string  `json:"note" example:"Transfer BCA"`
+}
+
+func (req DebtRequest) toModel() (models.Debt, error) {
+	d := models.Debt{
+		Direction:     req.Direction,
+		Counterparty:  strings.TrimSpace(req.Counterparty),
+		Principal:     req.Principal,
+		Description:   req.Description,
+		Status:        models.DebtOpen,
+		TransactionID: req.TransactionID,
+	}
+	if d.Direction != models.DebtPayable && d.Direction != models.DebtReceivable {
+		return d, errors.New("direction harus hutang atau piutang")
+	}
+	if d.Counterparty == "" {
+		return d, errors.New("counterparty wajib diisi")
+	}
+	if d.Principal <= 0 {
+		return d, errors.New("principal harus lebih dari 0")
+	}
+
+	d.StartDate = dayOf(time.Now())
+	if req.StartDate != "" {
+		start, err := parseDate(req.StartDate)
+		if err != nil {
+			return d, errors.New("start_date harus format YYYY-MM-DD")
+		}
+		d.StartDate = start
+	}
+	if req.DueDate != "" {
+		due, err := parseDate(req.DueDate)
+		if err != nil {
+			return d, errors.New("due_date harus format YYYY-MM-DD")
+		}
+		if due.Before(d.StartDate) {
+			return d, errors.New("due_date harus setelah start_date")
+		}
+		d.DueDate = &due
+	}
+	return d, nil
+}
+
+func (req DebtUpdateRequest) apply(d *models.Debt) error {
+	if req.Counterparty != nil {
+		if strings.TrimSpace(*req.Counterparty) == "" {
+			return &db.DebtValidationError{Reason: "counterparty tidak boleh kosong"}
+		}
+		d.Counterparty = strings.TrimSpace(*req.Counterparty)
+	}
+	if req.Description != nil {
+		d.Description = *req.Description
+	}
+	if req.DueDate != nil {
+		if *req.DueDate == "" {
+			d.DueDate = nil
+		} else {
+			due, err := parseDate(*req.DueDate)
+			if err != nil {
+				return &db.DebtValidationError{Reason: "due_date harus format YYYY-MM-DD"}
+			}
+			if due.Before(d.StartDate) {
+				return &db.DebtValidationError{Reason: "due_date harus setelah start_date"}
+			}
+			d.DueDate = &due
+		}
+	}
+	if req.Status != nil {
+		switch *req.Status {
+		case models.DebtWrittenOff:
+			d.Status = models.DebtWrittenOff
+		case models.DebtOpen:
+			if d.Outstanding() == 0 {
+				return &db.DebtValidationError{Reason: "hutang piutang sudah lunas"}
+			}
+			d.Status = models.DebtOpen
+		default:
+			return &db.DebtValidationError{Reason: "status hanya bisa diubah ke open atau written_off"}
+		}
+	}
+	return nil
+}
+
+// debtLinksChanged membuang cache dan mengirim ulang ringkasan dashboard untuk bulan-bulan
+// transaksi yang baru dihubungkan / dilepas dari hutang piutang
+func debtLinksChanged(actor Identity, changed []time.Time) {
+	if len(changed) == 0 {
+		return
+	}
+	invalidateDashboardCache(changed...)
+	go publishSummary(actor, changed...)
+}
+
+func toDebtResponse(d models.Debt, today time.Time) models.DebtResponse {
+	resp := models.DebtResponse{Debt: d, Outstanding: d.Outstanding(), Overdue: d.IsOverdue(today)}
+	if resp.Overdue {
+		resp.DaysOverdue = int(today.Sub(*d.DueDate).Hours() / 24)
+	}
+	return resp
+}
+
+func toDebtResponses(debts []models.Debt) []models.DebtResponse {
+	today := dayOf(time.Now())
+	list := make([]models.DebtResponse, 0, len(debts))
+	for _, d := range debts {
+		list = append(list, toDebtResponse(d, today))
+	}
+	return list
+}
+
+func writeDebtError(w http.ResponseWriter, err error) {
+	var invalid *db.DebtValidationError
+	var unlinkable *db.TransactionLinkError
+	switch {
+	case errors.As(err, &invalid), errors.As(err, &unlinkable):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, db.ErrDebtNotFound), errors.Is(err, db.ErrRepaymentNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, db.ErrDebtClosed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Gagal menyimpan hutang piutang", http.StatusInternalServerError)
+	}
+}
+
+func writeDebt(w http.ResponseWriter, status int, d *models.Debt) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(toDebtResponse(*d, dayOf(time.Now())))
+}
+
+// CreateDebt godoc
+// @Summary Catat hutang atau piutang
+// @Description direction hutang = kita meminjam, piutang = kita meminjamkan. transaction_id opsional menghubungkan transaksi pencairan approved yang belum dipakai pembayaran lain (piutang: pengeluaran, hutang: pemasukan).
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param debt body DebtRequest true "Hutang piutang"
+// @Success 201 {object} models.DebtResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/debts [post]
+func CreateDebt(w http.ResponseWriter, r *http.Request) {
+	var req DebtRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d, err := req.toModel()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d.CreatedBy = currentIdentity(r).UserID
+
+	changed, err := db.CreateDebt(&d, appZone)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	debtLinksChanged(currentIdentity(r), changed)
+	writeDebt(w, http.StatusCreated, &d)
+}
+
+// GetDebts godoc
+// @Summary Daftar hutang piutang
+// @Tags Debts
+// @Produce json
+// @Param direction query string false "hutang atau piutang"
+// @Param status query string false "open, paid atau written_off"
+// @Param counterparty query string false "Nama orang (tidak case sensitive)"
+// @Success 200 {array} models.DebtResponse
+// @Router /api/debts [get]
+func GetDebts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	debts, err := db.ListDebts(db.DebtFilter{
+		Direction:    q.Get("direction"),
+		Status:       q.Get("status"),
+		Counterparty: strings.TrimSpace(q.Get("counterparty")),
+	})
+	if err != nil {
+		http.Error(w, "Gagal mengambil hutang piutang", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toDebtResponses(debts))
+}
+
+// GetOverdueDebts godoc
+// @Summary Hutang piutang yang lewat jatuh tempo
+// @Description Masih open dan due_date sebelum hari ini, yang paling lama terlambat dulu
+// @Tags Debts
+// @Produce json
+// @Param direction query string false "hutang atau piutang"
+// @Success 200 {array} models.DebtResponse
+// @Router /api/debts/overdue [get]
+func GetOverdueDebts(w http.ResponseWriter, r *http.Request) {
+	today := dayOf(time.Now())
+	debts, err := db.ListDebts(db.DebtFilter{Direction: r.URL.Query().Get("direction"), OverdueAt: &today})
+	if err != nil {
+		http.Error(w, "Gagal mengambil hutang piutang", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toDebtResponses(debts))
+}
+
+// debtBalances menjumlahkan sisa hutang piutang open per orang. Nama dibandingkan tanpa
+// membedakan huruf besar kecil; nama yang ditampilkan adalah yang pertama ditemukan.
+func debtBalances(debts []models.Debt, today time.Time) []models.CounterpartyBalance {
+	index := map[string]int{}
+	var balances []models.CounterpartyBalance
+	for _, d := range debts {
+		if d.Status != models.DebtOpen {
+			continue
+		}
+		key := strings.ToLower(d.Counterparty)
+		i, ok := index[key]
+		if !ok {
+			i = len(balances)
+			index[key] = i
+			balances = append(balances, models.CounterpartyBalance{Counterparty: d.Counterparty})
+		}
+
+		b := &balances[i]
+		if d.Direction == models.DebtReceivable {
+			b.Receivable += d.Outstanding()
+		} else {
+			b.Payable += d.Outstanding()
+		}
+		b.Net = b.Receivable - b.Payable
+		b.OpenCount++
+		if d.IsOverdue(today) {
+			b.OverdueCount++
+		}
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Net != balances[j].Net {
+			return balances[i].Net > balances[j].Net
+		}
+		return balances[i].Counterparty < balances[j].Counterparty
+	})
+	return balances
+}
+
+// GetDebtBalances godoc
+// @Summary Sisa hutang piutang per orang
+// @Description Net positif berarti orang itu masih berhutang ke kita, negatif berarti kita yang berhutang
+// @Tags Debts
+// @Produce json
+// @Success 200 {array} models.CounterpartyBalance
+// @Router /api/debts/balances [get]
+func GetDebtBalances(w http.ResponseWriter, r *http.Request) {
+	debts, err := db.ListDebts(db.DebtFilter{Status: models.DebtOpen})
+	if err != nil {
+		http.Error(w, "Gagal mengambil hutang piutang", http.StatusInternalServerError)
+		return
+	}
+
+	balances := debtBalances(debts, dayOf(time.Now()))
+	if balances == nil {
+		balances = []models.CounterpartyBalance{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(balances)
+}
+
+// GetDebt godoc
+// @Summary Detail hutang piutang beserta riwayat pembayaran
+// @Tags Debts
+// @Produce json
+// @Param id path int true "Debt ID"
+// @Success 200 {object} models.DebtResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/debts/{id} [get]
+func GetDebt(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	d, err := db.GetDebt(id)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	writeDebt(w, http.StatusOK, d)
+}
+
+// UpdateDebt godoc
+// @Summary Ubah hutang piutang
+// @Description Semua field opsional. due_date kosong menghapus jatuh tempo. status written_off untuk mengikhlaskan / berhenti menagih, open untuk membuka lagi.
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path int true "Debt ID"
+// @Param debt body DebtUpdateRequest true "Perubahan"
+// @Success 200 {object} models.DebtResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/debts/{id} [put]
+func UpdateDebt(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req DebtUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := db.UpdateDebt(id, req.apply)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	writeDebt(w, http.StatusOK, d)
+}
+
+// DeleteDebt godoc
+// @Summary Hapus hutang piutang
+// @Description Pembayarannya ikut dihapus; transaksi yang terhubung tetap ada sebagai transaksi biasa
+// @Tags Debts
+// @Param id path int true "Debt ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/debts/{id} [delete]
+func DeleteDebt(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	changed, err := db.DeleteDebt(id, appZone)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	debtLinksChanged(currentIdentity(r), changed)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Hutang piutang berhasil dihapus"})
+}
+
+// AddRepayment godoc
+// @Summary Catat pembayaran hutang piutang
+// @Description Bisa dicicil. transaction_id opsional menghubungkan transaksi pembayaran approved yang belum dipakai pembayaran lain (piutang: pemasukan, hutang: pengeluaran). Otomatis lunas jika sisa habis.
+// @Tags Debts
+// @Accept json
+// @Produce json
+// @Param id path int true "Debt ID"
+// @Param repayment body RepaymentRequest true "Pembayaran"
+// @Success 201 {object} models.DebtResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/debts/{id}/repayments [post]
+func AddRepayment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req RepaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rep := models.DebtRepayment{
+		Amount:        req.Amount,
+		Date:          dayOf(time.Now()),
+		TransactionID: req.TransactionID,
+		Note:          req.Note,
+		CreatedBy:     currentIdentity(r).UserID,
+	}
+	if req.Date != "" {
+		date, err := parseDate(req.Date)
+		if err != nil {
+			http.Error(w, "date harus format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		rep.Date = date
+	}
+	if rep.Amount == 0 && rep.TransactionID != nil {
+		var tx models.Transaction
+		if err := db.DB.First(&tx, *rep.TransactionID).Error; err != nil {
+			http.Error(w, "Transaksi tidak ditemukan", http.StatusBadRequest)
+			return
+		}
+		rep.Amount = tx.Amount
+		if req.Date == "" {
+			rep.Date = dayOf(tx.TransactionAt)
+		}
+	}
+	if rep.Amount <= 0 {
+		http.Error(w, "amount harus lebih dari 0", http.StatusBadRequest)
+		return
+	}
+
+	_, changed, err := db.AddRepayment(id, &rep, appZone)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	debtLinksChanged(currentIdentity(r), changed)
+	d, err := db.GetDebt(id)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	writeDebt(w, http.StatusCreated, d)
+}
+
+// DeleteRepayment godoc
+// @Summary Batalkan pembayaran hutang piutang
+// @Description Sisa bertambah lagi dan status lunas dibuka kembali; transaksinya tidak dihapus
+// @Tags Debts
+// @Produce json
+// @Param id path int true "Repayment ID"
+// @Success 200 {object} models.DebtResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/debts/repayments/{id} [delete]
+func DeleteRepayment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	d, changed, err := db.DeleteRepayment(id, appZone)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	debtLinksChanged(currentIdentity(r), changed)
+	d, err = db.GetDebt(d.ID)
+	if err != nil {
+		writeDebtError(w, err)
+		return
+	}
+	writeDebt(w, http.StatusOK, d)
+}
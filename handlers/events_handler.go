@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+const (
+	sseHeartbeat = 25 * time.Second
+	sseBuffer    = 64
+)
+
+// canSee menentukan apakah viewer boleh menerima event: satu workspace dengan pelaku,
+// atau user yang sama jika tidak memakai workspace. Event global untuk semua.
+func (viewer Identity) canSee(e events.Event) bool {
+	if e.Global() {
+		return true
+	}
+	if viewer.Workspace != "" {
+		return e.Workspace == viewer.Workspace
+	}
+	return viewer.UserID != "" && e.Workspace == "" && e.UserID == viewer.UserID
+}
+
+func publish(eventType string, actor Identity, data interface{}) {
+	events.Publish(events.Event{
+		Type:      eventType,
+		UserID:    actor.UserID,
+		Workspace: actor.Workspace,
+		Data:      data,
+	})
+}
+
+// publishTransaction mem-publish event transaksi beserta ringkasan bulan yang berubah
+// (ringkasan hanya jika transaksi approved, karena hanya itu yang dihitung dashboard)
+func publishTransaction(eventType string, actor Identity, tx models.Transaction, affectsSummary bool) {
+	if eventType == events.TransactionDeleted {
+		publish(eventType, actor, map[string]interface{}{"id": tx.ID})
+	} else {
+		resp := toTransactionResponses([]models.Transaction{tx})[0]
+		publish(eventType, actor, resp)
+		// Transaksi baru / di-submit ulang yang menunggu manager
+		if tx.Status == models.StatusSubmitted {
+			publish(events.ApprovalRequested, actor, resp)
+		}
+	}
+	if affectsSummary {
+		go publishSummary(actor, tx.TransactionAt)
+	}
+}
+
+// publishStatusChange mem-publish perubahan status transaksi (submit, approve, reject) atas
+// nama pembuatnya di workspace pelaku. Jika pelaku memakai workspace, event juga dikirim ke
+// stream pribadi pembuat supaya pembuat yang tidak memakai workspace tetap menerimanya.
+func publishStatusChange(actor Identity, tx models.Transaction, affectsSummary bool) {
+	owner := Identity{UserID: tx.CreatedBy, Workspace: actor.Workspace}
+	publishTransaction(events.TransactionUpdated, owner, tx, affectsSummary)
+	if owner.UserID != "" && owner.Workspace != "" {
+		publish(events.TransactionUpdated, Identity{UserID: owner.UserID}, toTransactionResponses([]models.Transaction{tx})[0])
+	}
+}
+
+// publishSummary menghitung ulang saldo bulan-bulan yang berubah (dari rollup) dan
+// mengirimnya sebagai summary.recomputed supaya dashboard tidak perlu fetch ulang.
+func publishSummary(actor Identity, times ...time.Time) {
+	if len(times) == 0 {
+		return
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	first, last := times[0].In(appZone), times[len(times)-1].In(appZone)
+	from := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, appZone)
+	to := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, appZone).AddDate(0, 1, 0)
+
+	filter := TransactionFilter{From: &from, To: &to}.ForDashboard("")
+	balances, err := MonthlyBalances(db.DB, filter)
+	if err != nil {
+		log.Printf("hitung ringkasan untuk event gagal: %v", err)
+		return
+	}
+	publish(events.SummaryRecomputed, actor, map[string]interface{}{
+		"months":   cacheMonths(filter),
+		"balances": balances,
+	})
+}
+
+// StreamEvents godoc
+// @Summary Stream event real-time (Server-Sent Events)
+// @Description Mengirim transaction.created, transaction.updated, transaction.deleted, summary.recomputed, campaign.*, bill.* dan approval.requested milik workspace (X-Workspace-ID) atau user (X-User-ID) yang sama, serta anomaly.detected untuk semua. Event resync berarti ada event yang terlewat dan client sebaiknya fetch ulang. Komentar heartbeat dikirim tiap 25 detik.
+// @Tags Events
+// @Produce text/event-stream
+// @Param types query string false "Daftar tipe event dipisah koma (default semua)"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 401 {object} map[string]string
+// @Router /api/events [get]
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	viewer := currentIdentity(r)
+	if viewer.UserID == "" && viewer.Workspace == "" {
+		http.Error(w, "Header X-User-ID atau X-Workspace-ID wajib diisi", http.StatusUnauthorized)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming tidak didukung", http.StatusInternalServerError)
+		return
+	}
+
+	types := map[string]bool{}
+	if v := r.URL.Query().Get("types"); v != "" {
+		for _, t := range strings.Split(v, ",") {
+			types[strings.TrimSpace(t)] = true
+		}
+	}
+
+	sub := events.Default.Subscribe(func(e events.Event) bool {
+		return viewer.canSee(e) && (len(types) == 0 || types[e.Type])
+	}, sseBuffer)
+	defer events.Default.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // supaya nginx tidak mem-buffer stream
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if sub.Lagged() {
+				fmt.Fprint(w, "event: resync\ndata: {}\n\n")
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+		}
+		flusher.Flush()
+	}
+}
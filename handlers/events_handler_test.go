@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"testing"
+
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+func TestCanSee(t *testing.T) {
+	tests := []struct {
+		name   string
+		viewer Identity
+		event  events.Event
+		want   bool
+	}{
+		{"same workspace", Identity{UserID: "ani", Workspace: "ws"}, events.Event{UserID: "budi", Workspace: "ws"}, true},
+		{"other workspace", Identity{UserID: "ani", Workspace: "ws"}, events.Event{UserID: "budi", Workspace: "other"}, false},
+		{"workspace viewer, personal event", Identity{UserID: "budi", Workspace: "ws"}, events.Event{UserID: "budi"}, false},
+		{"same user without workspace", Identity{UserID: "budi"}, events.Event{UserID: "budi"}, true},
+		{"other user without workspace", Identity{UserID: "ani"}, events.Event{UserID: "budi"}, false},
+		{"personal viewer, workspace event", Identity{UserID: "budi"}, events.Event{UserID: "budi", Workspace: "ws"}, false},
+		{"anonymous viewer", Identity{}, events.Event{UserID: "budi"}, false},
+		{"global event, workspace viewer", Identity{UserID: "ani", Workspace: "ws"}, events.Event{}, true},
+		{"global event, personal viewer", Identity{UserID: "budi"}, events.Event{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.canSee(tt.event); got != tt.want {
+				t.Errorf("canSee(%+v) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+}
+
+// Manager di workspace meng-approve transaksi milik user yang tidak memakai workspace:
+// pembuat dan anggota workspace manager sama-sama menerima event-nya
+func TestPublishStatusChangeReachesOwner(t *testing.T) {
+	viewers := map[string]Identity{
+		"owner":     {UserID: "budi"},
+		"workspace": {UserID: "ani", Workspace: "kantor"},
+		"stranger":  {UserID: "cici"},
+	}
+	subs := map[string]*events.Subscription{}
+	for name, viewer := range viewers {
+		subs[name] = events.Default.Subscribe(viewer.canSee, 8)
+		defer events.Default.Unsubscribe(subs[name])
+	}
+
+	manager := Identity{UserID: "mira", Role: "manager", Workspace: "kantor"}
+	tx := models.Transaction{ID: 7, Type: "pengeluaran", Status: models.StatusApproved, CreatedBy: "budi"}
+	publishStatusChange(manager, tx, false)
+
+	for name, want := range map[string]int{"owner": 1, "workspace": 1, "stranger": 0} {
+		if got := len(subs[name].C); got != want {
+			t.Errorf("%s received %d events, want %d", name, got, want)
+			continue
+		}
+		if want == 0 {
+			continue
+		}
+		e := <-subs[name].C
+		if e.Type != events.TransactionUpdated || e.UserID != "budi" {
+			t.Errorf("%s received %s from %q, want %s from budi", name, e.Type, e.UserID, events.TransactionUpdated)
+		}
+	}
+}
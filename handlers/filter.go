@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cash-flow-go/models"
+
+	"gorm.io/gorm"
+)
+
+// Preset periode untuk parameter period
+const (
+	PeriodThisMonth  = "this_month"
+	PeriodLastMonth  = "last_month"
+	PeriodLast30Days = "last_30_days"
+	PeriodYTD        = "ytd"
+	PeriodCustom     = "custom"
+)
+
+// TransactionFilter adalah filter yang sama untuk daftar transaksi dan semua endpoint dashboard,
+// supaya angka di dashboard selalu cocok dengan list view.
+type TransactionFilter struct {
+	Type        string
+	Category    string
+	Account     string
+	Payee       string
+	Status      string
+	Description string
+	MinAmount   *float64
+	MaxAmount   *float64
+
+	// From inklusif, To eksklusif (sudah dikonversi dari tanggal zona aplikasi)
+	From *time.Time
+	To   *time.Time
+
+	// ExcludeDebts membuang transaksi pencairan / pembayaran hutang piutang (debt_id terisi),
+	// karena pinjaman bukan pemasukan atau pengeluaran
+	ExcludeDebts bool
+}
+
+// HasRange true jika filter membatasi rentang waktu
+func (f TransactionFilter) HasRange() bool {
+	return f.From != nil || f.To != nil
+}
+
+// parseTransactionFilter membaca query:
+// type, category, account, payee, status, description, min_amount, max_amount,
+// period (this_month, last_month, last_30_days, ytd, custom) dan from / to.
+// Tanggal YYYY-MM-DD dibaca dalam zona aplikasi (APP_TIMEZONE) dan to bersifat inklusif (sampai akhir hari).
+// start_date / end_date tetap diterima sebagai alias from / to.
+func parseTransactionFilter(r *http.Request) (TransactionFilter, error) {
+	q := r.URL.Query()
+	f := TransactionFilter{
+		Type:        q.Get("type"),
+		Category:    q.Get("category"),
+		Account:     q.Get("account"),
+		Payee:       q.Get("payee"),
+		Status:      q.Get("status"),
+		Description: q.Get("description"),
+	}
+
+	if f.Type != "" && f.Type != "pemasukan" && f.Type != "pengeluaran" {
+		return f, errors.New("type harus pemasukan atau pengeluaran")
+	}
+	switch f.Status {
+	case "", models.StatusDraft, models.StatusSubmitted, models.StatusApproved, models.StatusRejected:
+	default:
+		return f, errors.New("status tidak valid")
+	}
+
+	var err error
+	if f.MinAmount, err = parseAmount(q.Get("min_amount")); err != nil {
+		return f, errors.New("min_amount harus berupa angka")
+	}
+	if f.MaxAmount, err = parseAmount(q.Get("max_amount")); err != nil {
+		return f, errors.New("max_amount harus berupa angka")
+	}
+
+	from, to := q.Get("from"), q.Get("to")
+	if from == "" {
+		from = q.Get("start_date")
+	}
+	if to == "" {
+		to = q.Get("end_date")
+	}
+
+	period := q.Get("period")
+	if period == "" && (from != "" || to != "") {
+		period = PeriodCustom
+	}
+	if period == "" {
+		return f, nil
+	}
+
+	now := time.Now().In(appZone)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, appZone)
+	tomorrow := today.AddDate(0, 0, 1)
+	var start, end time.Time
+
+	switch period {
+	case PeriodThisMonth:
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, appZone)
+		end = start.AddDate(0, 1, 0)
+	case PeriodLastMonth:
+		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, appZone)
+		start = end.AddDate(0, -1, 0)
+	case PeriodLast30Days:
+		start, end = today.AddDate(0, 0, -29), tomorrow
+	case PeriodYTD:
+		start, end = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, appZone), tomorrow
+	case PeriodCustom:
+		if from != "" {
+			if start, err = parseFilterTime(from, false); err != nil {
+				return f, errors.New("from harus format YYYY-MM-DD atau RFC3339")
+			}
+		}
+		if to != "" {
+			if end, err = parseFilterTime(to, true); err != nil {
+				return f, errors.New("to harus format YYYY-MM-DD atau RFC3339")
+			}
+		}
+		if !start.IsZero() && !end.IsZero() && !end.After(start) {
+			return f, errors.New("to harus setelah from")
+		}
+	default:
+		return f, errors.New("period harus this_month, last_month, last_30_days, ytd atau custom")
+	}
+
+	if !start.IsZero() {
+		f.From = &start
+	}
+	if !end.IsZero() {
+		f.To = &end
+	}
+	return f, nil
+}
+
+// parseFilterTime menerima tanggal zona aplikasi (YYYY-MM-DD) atau waktu RFC3339.
+// Untuk batas akhir, tanggal saja berarti sampai akhir hari tersebut.
+func parseFilterTime(v string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", v, appZone)
+	if err != nil {
+		return t, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func parseAmount(v string) (*float64, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// Apply menerapkan filter ke query builder tabel transactions
+func (f TransactionFilter) Apply(b *gorm.DB) *gorm.DB {
+	if f.Type != "" {
+		b = b.Where("transactions.type = ?", f.Type)
+	}
+	if f.Category != "" {
+		b = b.Where("transactions.category = ?", f.Category)
+	}
+	if f.Account != "" {
+		b = b.Where("transactions.account = ?", f.Account)
+	}
+	if f.Payee != "" {
+		b = b.Where("transactions.payee = ?", f.Payee)
+	}
+	if f.Status != "" {
+		b = b.Where("transactions.status = ?", f.Status)
+	}
+	if f.From != nil {
+		b = b.Where("transactions.transaction_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		b = b.Where("transactions.transaction_at < ?", *f.To)
+	}
+	if f.Description != "" {
+		b = b.Where("transactions.description LIKE ?", "%"+f.Description+"%")
+	}
+	if f.MinAmount != nil {
+		b = b.Where("transactions.amount >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		b = b.Where("transactions.amount <= ?", *f.MaxAmount)
+	}
+	if f.ExcludeDebts {
+		b = b.Where("transactions.debt_id IS NULL")
+	}
+	return b
+}
+
+// ForDashboard menyiapkan filter dashboard: hanya transaksi approved yang bukan hutang piutang
+// yang dihitung. defaultType dipakai chart yang memang khusus pemasukan / pengeluaran jika type tidak dikirim.
+func (f TransactionFilter) ForDashboard(defaultType string) TransactionFilter {
+	f.Status = models.StatusApproved
+	f.ExcludeDebts = true
+	if f.Type == "" {
+		f.Type = defaultType
+	}
+	return f
+}
+
+// summaryDay mengembalikan tanggal t (YYYY-MM-DD) jika t tepat tengah malam di zona aplikasi
+func summaryDay(t time.Time) (string, bool) {
+	local := t.In(appZone)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, appZone)
+	return local.Format("2006-01-02"), local.Equal(midnight)
+}
+
+// usesSummaries true jika filter bisa dijawab dari rollup harian: hanya transaksi approved
+// tanpa hutang piutang, batas waktu jatuh tepat di pergantian hari, dan tanpa filter payee /
+// description / amount.
+// byCategories untuk tabel per elemen categories, yang tidak bisa difilter kategori utama.
+func (f TransactionFilter) usesSummaries(byCategories bool) bool {
+	if f.Status != models.StatusApproved || !f.ExcludeDebts || f.Payee != "" || f.Description != "" ||
+		f.MinAmount != nil || f.MaxAmount != nil || (byCategories && f.Category != "") {
+		return false
+	}
+	if f.From != nil {
+		if _, ok := summaryDay(*f.From); !ok {
+			return false
+		}
+	}
+	if f.To != nil {
+		if _, ok := summaryDay(*f.To); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// applySummary menerapkan filter ke tabel rollup (daily_summaries / daily_category_summaries).
+// Pastikan usesSummaries true sebelum memakai ini.
+func (f TransactionFilter) applySummary(b *gorm.DB) *gorm.DB {
+	if f.Type != "" {
+		b = b.Where("type = ?", f.Type)
+	}
+	if f.Category != "" {
+		b = b.Where("category = ?", f.Category)
+	}
+	if f.Account != "" {
+		b = b.Where("account = ?", f.Account)
+	}
+	if f.From != nil {
+		day, _ := summaryDay(*f.From)
+		b = b.Where("day >= ?::date", day)
+	}
+	if f.To != nil {
+		day, _ := summaryDay(*f.To)
+		b = b.Where("day < ?::date", day)
+	}
+	return b
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/forecast"
+	"cash-flow-go/models"
+)
+
+// forecastSource menghasilkan arus kas pasti antara from dan to (inklusif, tanggal lokal).
+// Sumber baru cukup ditambahkan ke forecastSources.
+type forecastSource func(from, to time.Time) ([]forecast.Event, error)
+
+var forecastSources = []forecastSource{recurringEvents, installmentEvents, billEvents}
+
+func recurringEvents(from, to time.Time) ([]forecast.Event, error) {
+	var list []models.RecurringTransaction
+	if err := db.DB.Where("is_active = ?", true).Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	var events []forecast.Event
+	for _, rt := range list {
+		for _, d := range rt.Occurrences(from, to) {
+			events = append(events, forecast.Event{
+				Date:        d,
+				Amount:      rt.SignedAmount(),
+				Source:      "recurring",
+				SourceID:    rt.ID,
+				Description: rt.Description,
+			})
+		}
+	}
+	return events, nil
+}
+
+// currentBalance adalah saldo semua transaksi approved sampai sekarang
+func currentBalance() (float64, error) {
+	var balance float64
+	err := db.DB.Model(&models.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = 'pemasukan' THEN amount ELSE -amount END), 0)").
+		Where("status = ? AND transaction_at <= ?", models.StatusApproved, time.Now()).
+		Scan(&balance).Error
+	return balance, err
+}
+
+// variableProfiles menyusun profil musiman pengeluaran variabel per kategori dari
+// histori 12 bulan penuh terakhir. Transaksi realisasi recurring, cicilan dan hutang piutang tidak dihitung.
+func variableProfiles(today time.Time) ([]forecast.Profile, error) {
+	type row struct {
+		Category   string
+		MonthStart time.Time
+		Total      float64
+	}
+
+	now := time.Now().In(appZone)
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, appZone)
+	from := to.AddDate(0, -forecast.HistoryMonths, 0)
+	filter := TransactionFilter{Type: "pengeluaran", From: &from, To: &to}.ForDashboard("")
+
+	var rows []row
+	err := filter.Apply(db.DB.Model(&models.Transaction{})).
+		Where("transactions.recurring_id IS NULL AND transactions.debt_id IS NULL AND transactions.installment_id IS NULL").
+		Where("NOT EXISTS (SELECT 1 FROM bill_payments WHERE bill_payments.transaction_id = transactions.id)").
+		Select("transactions.category AS category, " + periodSQL("month") + " AS month_start, SUM(amount) AS total").
+		Group("category, month_start").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	history := map[string][]forecast.MonthTotal{}
+	var categories []string
+	for _, r := range rows {
+		if _, ok := history[r.Category]; !ok {
+			categories = append(categories, r.Category)
+		}
+		history[r.Category] = append(history[r.Category], forecast.MonthTotal{Month: r.MonthStart, Total: r.Total})
+	}
+
+	var profiles []forecast.Profile
+	for _, c := range categories {
+		p := forecast.BuildProfile(c, history[c], today)
+		if p.Baseline > 0 || p.StdDev > 0 {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+// GetForecast godoc
+// @Summary Proyeksi saldo harian
+// @Description Proyeksi saldo mulai besok dari saldo sekarang, arus kas pasti (recurring transaction dan cicilan yang belum dibayar) dan rata-rata musiman pengeluaran variabel per kategori. Band best / worst = expected ± simpangan baku pengeluaran variabel yang terakumulasi (tumbuh sebanding akar jumlah hari). first_negative_date adalah tanggal pertama saldo expected diproyeksikan negatif.
+// @Tags Forecast
+// @Produce json
+// @Param days query int false "30, 60 atau 90 (default 30)"
+// @Success 200 {object} models.ForecastResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/forecast [get]
+func GetForecast(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		days, _ = strconv.Atoi(v)
+		if days != 30 && days != 60 && days != 90 {
+			http.Error(w, "days harus 30, 60 atau 90", http.StatusBadRequest)
+			return
+		}
+	}
+
+	today := dayOf(time.Now())
+	from, to := today.AddDate(0, 0, 1), today.AddDate(0, 0, days)
+
+	balance, err := currentBalance()
+	if err != nil {
+		http.Error(w, "Gagal menghitung saldo", http.StatusInternalServerError)
+		return
+	}
+
+	var events []forecast.Event
+	for _, source := range forecastSources {
+		e, err := source(from, to)
+		if err != nil {
+			http.Error(w, "Gagal mengambil arus kas terjadwal", http.StatusInternalServerError)
+			return
+		}
+		events = append(events, e...)
+	}
+	forecast.SortEvents(events)
+
+	profiles, err := variableProfiles(today)
+	if err != nil {
+		http.Error(w, "Gagal mengambil histori pengeluaran", http.StatusInternalServerError)
+		return
+	}
+
+	result := forecast.Project(balance, from, days, events, profiles)
+
+	resp := models.ForecastResponse{
+		Days:           days,
+		CurrentBalance: balance,
+		Daily:          make([]models.ForecastDay, 0, len(result.Days)),
+		Events:         []models.ForecastEvent{},
+		Variable:       []models.ForecastCategory{},
+	}
+	for _, d := range result.Days {
+		resp.Daily = append(resp.Daily, models.ForecastDay{
+			Date:     d.Date.Format("2006-01-02"),
+			Known:    d.Known,
+			Variable: d.Variable,
+			Expected: d.Expected,
+			Best:     d.Best,
+			Worst:    d.Worst,
+		})
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, models.ForecastEvent{
+			Date:        e.Date.Format("2006-01-02"),
+			Amount:      e.Amount,
+			Source:      e.Source,
+			SourceID:    e.SourceID,
+			Description: e.Description,
+		})
+	}
+	for _, p := range profiles {
+		resp.Variable = append(resp.Variable, models.ForecastCategory{
+			Category: p.Category,
+			Monthly:  p.Baseline,
+			StdDev:   p.StdDev,
+		})
+	}
+	if result.FirstNegative != nil {
+		d := result.FirstNegative.Format("2006-01-02")
+		resp.FirstNegativeDate = &d
+	}
+	if result.FirstNegativeWorst != nil {
+		d := result.FirstNegativeWorst.Format("2006-01-02")
+		resp.FirstNegativeWorst = &d
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+const RoleManager = "manager"
+
+// Identity adalah user yang sedang mengakses API.
+// Service ini belum punya login sendiri, jadi identitas dibaca dari header
+// yang di-set oleh gateway / auth proxy di depan service.
+type Identity struct {
+	UserID    string
+	Role      string
+	Workspace string
+}
+
+func currentIdentity(r *http.Request) Identity {
+	return Identity{
+		UserID:    strings.TrimSpace(r.Header.Get("X-User-ID")),
+		Role:      strings.ToLower(strings.TrimSpace(r.Header.Get("X-User-Role"))),
+		Workspace: strings.TrimSpace(r.Header.Get("X-Workspace-ID")),
+	}
+}
+
+func (i Identity) IsManager() bool {
+	return i.Role == RoleManager
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/forecast"
+	"cash-flow-go/models"
+
+	"github.com/gorilla/mux"
+)
+
+const maxInstallmentTenor = 60
+
+// InstallmentPlanRequest adalah body untuk membuat plan cicilan. first_due_date format YYYY-MM-DD.
+// interest_rate adalah bunga flat persen per bulan dari pokok (0 untuk cicilan 0%).
+// payee dipakai untuk mencocokkan transaksi pembayaran, default sama dengan provider.
+type InstallmentPlanRequest struct {
+	Name         string  `json:"name" example:"iPhone 15"`
+	Provider     string  `json:"provider" example:"Kredivo"`
+	Payee        string  `json:"payee" example:"Kredivo"`
+	Account      string  `json:"account" example:"bca"`
+	Category     string  `json:"category" example:"elektronik"`
+	Principal    float64 `json:"principal" example:"12000000"`
+	Tenor        int     `json:"tenor" example:"12"`
+	InterestRate float64 `json:"interest_rate" example:"0"`
+	AdminFee     float64 `json:"admin_fee" example:"50000"`
+	MonthlyFee   float64 `json:"monthly_fee" example:"0"`
+	FirstDueDate string  `json:"first_due_date" example:"2025-09-05"`
+}
+
+// PayInstallmentRequest menandai cicilan lunas secara manual. number kosong berarti cicilan
+// belum dibayar paling awal; transaction_id opsional menghubungkan transaksi pembayarannya
+// (pengeluaran approved yang belum dipakai pembayaran lain).
+type PayInstallmentRequest struct {
+	Number        int    `json:"number,omitempty" example:"3"`
+	TransactionID *uint  `json:"transaction_id,omitempty" example:"88"`
+	PaidAt        string `json:"paid_at,omitempty" example:"2025-11-04"`
+}
+
+func (req InstallmentPlanRequest) toModel() (models.InstallmentPlan, error) {
+	p := models.InstallmentPlan{
+		Name:         strings.TrimSpace(req.Name),
+		Provider:     strings.TrimSpace(req.Provider),
+		Payee:        strings.TrimSpace(req.Payee),
+		Account:      req.Account,
+		Category:     req.Category,
+		Principal:    req.Principal,
+		Tenor:        req.Tenor,
+		InterestRate: req.InterestRate,
+		AdminFee:     req.AdminFee,
+		MonthlyFee:   req.MonthlyFee,
+		Status:       models.PlanActive,
+	}
+	if p.Name == "" {
+		return p, errors.New("name wajib diisi")
+	}
+	if p.Payee == "" {
+		p.Payee = p.Provider
+	}
+	if p.Principal <= 0 {
+		return p, errors.New("principal harus lebih dari 0")
+	}
+	if p.Tenor <= 0 || p.Tenor > maxInstallmentTenor {
+		return p, fmt.Errorf("tenor harus 1 sampai %d bulan", maxInstallmentTenor)
+	}
+	if p.InterestRate < 0 || p.AdminFee < 0 || p.MonthlyFee < 0 {
+		return p, errors.New("interest_rate, admin_fee dan monthly_fee tidak boleh negatif")
+	}
+
+	var err error
+	if p.FirstDueDate, err = parseDate(req.FirstDueDate); err != nil {
+		return p, errors.New("first_due_date wajib diisi dengan format YYYY-MM-DD")
+	}
+	return p, nil
+}
+
+func writeInstallmentError(w http.ResponseWriter, err error) {
+	var invalid *db.TransactionLinkError
+	switch {
+	case errors.As(err, &invalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, db.ErrPlanNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, db.ErrNoPendingInstallment), errors.Is(err, db.ErrInstallmentNotPaid):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Gagal menyimpan plan cicilan", http.StatusInternalServerError)
+	}
+}
+
+// writePlan mengirim plan terbaru dari database beserta ringkasannya
+func writePlan(w http.ResponseWriter, status int, id uint) {
+	p, err := db.GetInstallmentPlan(id)
+	if err != nil {
+		writeInstallmentError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(p.Summarize(dayOf(time.Now())))
+}
+
+// matchInstallment dipanggil lewat matchPayment: jika transaksi pengeluaran approved cocok
+// dengan cicilan yang belum dibayar, cicilan itu ditandai lunas dan mengembalikan true.
+func matchInstallment(tx models.Transaction) bool {
+	inst, err := db.MatchInstallment(tx)
+	if err != nil {
+		log.Printf("pencocokan cicilan untuk transaksi %d gagal: %v", tx.ID, err)
+		return false
+	}
+	if inst == nil {
+		return false
+	}
+	log.Printf("transaksi %d dicatat sebagai cicilan ke-%d plan %d", tx.ID, inst.Number, inst.PlanID)
+	return true
+}
+
+// installmentEvents memasukkan cicilan belum dibayar ke forecast. Cicilan yang sudah lewat
+// jatuh tempo tapi belum dibayar dianggap dibayar di hari pertama proyeksi.
+func installmentEvents(from, to time.Time) ([]forecast.Event, error) {
+	plans, err := db.PendingInstallmentPlans(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []forecast.Event
+	for _, p := range plans {
+		for _, inst := range p.Installments {
+			date := inst.DueDate
+			if date.Before(from) {
+				date = from
+			}
+			events = append(events, forecast.Event{
+				Date:        date,
+				Amount:      -inst.Amount,
+				Source:      "installment",
+				SourceID:    p.ID,
+				Description: fmt.Sprintf("Cicilan %s %d/%d", p.Name, inst.Number, p.Tenor),
+			})
+		}
+	}
+	return events, nil
+}
+
+// CreateInstallmentPlan godoc
+// @Summary Tambah plan cicilan / paylater
+// @Description Jadwal cicilan bulanan dibuat otomatis: pokok dibagi rata, bunga flat interest_rate persen per bulan dari pokok, monthly_fee tiap bulan dan admin_fee di cicilan pertama. Transaksi pengeluaran approved dengan payee yang sama dan nominal yang cocok otomatis menandai cicilan lunas.
+// @Tags Installments
+// @Accept json
+// @Produce json
+// @Param plan body InstallmentPlanRequest true "Plan cicilan"
+// @Success 201 {object} models.InstallmentPlanResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/installments [post]
+func CreateInstallmentPlan(w http.ResponseWriter, r *http.Request) {
+	var req InstallmentPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p, err := req.toModel()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p.CreatedBy = currentIdentity(r).UserID
+
+	if err := db.CreateInstallmentPlan(&p); err != nil {
+		writeInstallmentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(p.Summarize(dayOf(time.Now())))
+}
+
+// GetInstallmentPlans godoc
+// @Summary Daftar plan cicilan beserta sisa kewajiban
+// @Tags Installments
+// @Produce json
+// @Param status query string false "active, completed atau cancelled"
+// @Success 200 {object} map[string]interface{} "plans dan total_remaining"
+// @Router /api/installments [get]
+func GetInstallmentPlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := db.ListInstallmentPlans(r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, "Gagal mengambil plan cicilan", http.StatusInternalServerError)
+		return
+	}
+
+	today := dayOf(time.Now())
+	list := make([]models.InstallmentPlanResponse, 0, len(plans))
+	var remaining float64
+	for _, p := range plans {
+		s := p.Summarize(today)
+		remaining += s.Remaining
+		list = append(list, s)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"plans":           list,
+		"total_remaining": remaining,
+	})
+}
+
+// GetInstallmentPlan godoc
+// @Summary Detail plan cicilan beserta jadwal
+// @Tags Installments
+// @Produce json
+// @Param id path int true "Plan ID"
+// @Success 200 {object} models.InstallmentPlanResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/installments/{id} [get]
+func GetInstallmentPlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	writePlan(w, http.StatusOK, id)
+}
+
+// PayInstallment godoc
+// @Summary Tandai cicilan lunas secara manual
+// @Description Untuk pembayaran yang tidak tercocokkan otomatis (payee berbeda, dibayar gabungan, dll). transaction_id harus pengeluaran approved yang belum dipakai tagihan, cicilan atau hutang piutang.
+// @Tags Installments
+// @Accept json
+// @Produce json
+// @Param id path int true "Plan ID"
+// @Param payment body PayInstallmentRequest true "Pembayaran"
+// @Success 200 {object} models.InstallmentPlanResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/installments/{id}/pay [post]
+func PayInstallment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req PayInstallmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	paidAt := time.Now()
+	if req.PaidAt != "" {
+		date, err := parseDate(req.PaidAt)
+		if err != nil {
+			http.Error(w, "paid_at harus format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		paidAt = date
+	}
+
+	if _, err := db.PayInstallment(id, req.Number, req.TransactionID, paidAt); err != nil {
+		writeInstallmentError(w, err)
+		return
+	}
+	writePlan(w, http.StatusOK, id)
+}
+
+// UnpayInstallment godoc
+// @Summary Batalkan pembayaran cicilan
+// @Description Cicilan kembali belum dibayar; transaksinya tidak dihapus
+// @Tags Installments
+// @Produce json
+// @Param id path int true "Plan ID"
+// @Param number path int true "Nomor cicilan"
+// @Success 200 {object} models.InstallmentPlanResponse
+// @Failure 409 {object} map[string]string
+// @Router /api/installments/{id}/installments/{number}/payment [delete]
+func UnpayInstallment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	number, err := strconv.Atoi(mux.Vars(r)["number"])
+	if err != nil || number <= 0 {
+		http.Error(w, "Nomor cicilan tidak valid", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := db.UnpayInstallmentNumber(id, number); err != nil {
+		writeInstallmentError(w, err)
+		return
+	}
+	writePlan(w, http.StatusOK, id)
+}
+
+// CancelInstallmentPlan godoc
+// @Summary Tutup plan cicilan
+// @Description Untuk pelunasan dipercepat atau pembatalan: sisa cicilan tidak lagi dihitung sebagai kewajiban maupun di forecast
+// @Tags Installments
+// @Produce json
+// @Param id path int true "Plan ID"
+// @Success 200 {object} models.InstallmentPlanResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/installments/{id}/cancel [post]
+func CancelInstallmentPlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.SetInstallmentPlanStatus(id, models.PlanCancelled); err != nil {
+		writeInstallmentError(w, err)
+		return
+	}
+	writePlan(w, http.StatusOK, id)
+}
+
+// DeleteInstallmentPlan godoc
+// @Summary Hapus plan cicilan
+// @Description Jadwal ikut dihapus; transaksi pembayaran tetap ada sebagai transaksi biasa
+// @Tags Installments
+// @Param id path int true "Plan ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/installments/{id} [delete]
+func DeleteInstallmentPlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.DeleteInstallmentPlan(id); err != nil {
+		writeInstallmentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Plan cicilan berhasil dihapus"})
+}
@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+)
+
+const (
+	defaultNetWorthMonths = 12
+	maxNetWorthMonths     = 120
+)
+
+// NetWorthItemRequest adalah body untuk membuat / mengubah item net worth.
+// Saat membuat, value dan valued_at (opsional, default hari ini) langsung dicatat
+// sebagai penilaian pertama. Saat update semua field opsional.
+type NetWorthItemRequest struct {
+	Kind     *string  `json:"kind" example:"asset"`
+	Category *string  `json:"category" example:"emas"`
+	Name     *string  `json:"name" example:"Emas Antam 10 gram"`
+	Note     *string  `json:"note" example:"Disimpan di brankas"`
+	ClosedAt *string  `json:"closed_at" example:"2026-01-31"`
+	Value    *float64 `json:"value" example:"12500000"`
+	ValuedAt string   `json:"valued_at" example:"2025-08-31"`
+}
+
+// ValuationRequest adalah body untuk mencatat nilai item pada satu tanggal
+type ValuationRequest struct {
+	Date  string  `json:"date" example:"2025-08-31"`
+	Value float64 `json:"value" example:"12500000"`
+	Note  string  `json:"note" example:"Harga buyback"`
+}
+
+func (req NetWorthItemRequest) apply(item *models.NetWorthItem) error {
+	if req.Kind != nil {
+		item.Kind = *req.Kind
+	}
+	if item.Kind != models.NetWorthAsset && item.Kind != models.NetWorthLiability {
+		return errors.New("kind harus asset atau liability")
+	}
+	if req.Category != nil {
+		item.Category = strings.ToLower(strings.TrimSpace(*req.Category))
+	}
+	if !models.IsValidNetWorthCategory(item.Kind, item.Category) {
+		return errors.New("category untuk " + item.Kind + " harus salah satu dari: " +
+			strings.Join(models.NetWorthCategories[item.Kind], ", "))
+	}
+	if req.Name != nil {
+		item.Name = strings.TrimSpace(*req.Name)
+	}
+	if item.Name == "" {
+		return errors.New("name wajib diisi")
+	}
+	if req.Note != nil {
+		item.Note = *req.Note
+	}
+	if req.ClosedAt != nil {
+		if *req.ClosedAt == "" {
+			item.ClosedAt = nil
+		} else {
+			closed, err := parseDate(*req.ClosedAt)
+			if err != nil {
+				return errors.New("closed_at harus format YYYY-MM-DD")
+			}
+			item.ClosedAt = &closed
+		}
+	}
+	return nil
+}
+
+func (req ValuationRequest) toModel(itemID uint) (models.NetWorthValuation, error) {
+	v := models.NetWorthValuation{ItemID: itemID, Value: req.Value, Note: req.Note}
+	if v.Value < 0 {
+		return v, errors.New("value tidak boleh negatif")
+	}
+	if req.Date == "" {
+		v.Date = dayOf(time.Now())
+		return v, nil
+	}
+	date, err := parseDate(req.Date)
+	if err != nil {
+		return v, errors.New("date harus format YYYY-MM-DD")
+	}
+	if date.After(dayOf(time.Now())) {
+		return v, errors.New("date tidak boleh di masa depan")
+	}
+	v.Date = date
+	return v, nil
+}
+
+func writeNetWorthError(w http.ResponseWriter, err error) {
+	if errors.Is(err, db.ErrNetWorthItemNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, "Gagal menyimpan item net worth", http.StatusInternalServerError)
+}
+
+// CreateNetWorthItem godoc
+// @Summary Tambah aset atau liabilitas
+// @Description Kategori asset: tabungan, emas, properti, investasi, kendaraan, lainnya. Kategori liability: kpr, kredit kendaraan, paylater, kartu kredit, pinjaman, lainnya. Nilai liabilitas dicatat positif (sisa utang).
+// @Tags Net Worth
+// @Accept json
+// @Produce json
+// @Param item body NetWorthItemRequest true "Item"
+// @Success 201 {object} models.NetWorthItem
+// @Failure 400 {object} map[string]string
+// @Router /api/net-worth/items [post]
+func CreateNetWorthItem(w http.ResponseWriter, r *http.Request) {
+	var req NetWorthItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item := models.NetWorthItem{CreatedBy: currentIdentity(r).UserID}
+	if err := req.apply(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Value != nil {
+		v, err := ValuationRequest{Date: req.ValuedAt, Value: *req.Value}.toModel(0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		item.Valuations = []models.NetWorthValuation{v}
+	}
+
+	if err := db.DB.Create(&item).Error; err != nil {
+		http.Error(w, "Gagal menyimpan item net worth", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(item)
+}
+
+// GetNetWorthItems godoc
+// @Summary Daftar aset dan liabilitas
+// @Tags Net Worth
+// @Produce json
+// @Success 200 {array} models.NetWorthItem
+// @Router /api/net-worth/items [get]
+func GetNetWorthItems(w http.ResponseWriter, r *http.Request) {
+	items, err := db.ListNetWorthItems()
+	if err != nil {
+		http.Error(w, "Gagal mengambil item net worth", http.StatusInternalServerError)
+		return
+	}
+	if items == nil {
+		items = []models.NetWorthItem{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+// UpdateNetWorthItem godoc
+// @Summary Ubah aset atau liabilitas
+// @Description Semua field opsional. Isi closed_at saat aset dijual atau utang lunas (string kosong untuk membuka lagi). value / valued_at diabaikan, pakai endpoint valuations.
+// @Tags Net Worth
+// @Accept json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Param item body NetWorthItemRequest true "Perubahan"
+// @Success 200 {object} models.NetWorthItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/net-worth/items/{id} [put]
+func UpdateNetWorthItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req NetWorthItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item, err := db.GetNetWorthItem(id)
+	if err != nil {
+		writeNetWorthError(w, err)
+		return
+	}
+	if err := req.apply(item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := db.DB.Omit("Valuations").Save(item).Error; err != nil {
+		writeNetWorthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(item)
+}
+
+// DeleteNetWorthItem godoc
+// @Summary Hapus aset atau liabilitas
+// @Description Semua penilaiannya ikut dihapus. Untuk aset yang dijual / utang lunas, lebih baik isi closed_at supaya histori tetap ada.
+// @Tags Net Worth
+// @Param id path int true "Item ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/net-worth/items/{id} [delete]
+func DeleteNetWorthItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.DeleteNetWorthItem(id); err != nil {
+		writeNetWorthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Item net worth berhasil dihapus"})
+}
+
+// AddValuation godoc
+// @Summary Catat nilai aset / sisa utang
+// @Description Satu nilai per tanggal; mencatat ulang tanggal yang sama menimpa nilai sebelumnya. Tanpa date dicatat untuk hari ini.
+// @Tags Net Worth
+// @Accept json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Param valuation body ValuationRequest true "Penilaian"
+// @Success 201 {object} models.NetWorthValuation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/net-worth/items/{id}/valuations [post]
+func AddValuation(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req ValuationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	v, err := req.toModel(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := db.GetNetWorthItem(id); err != nil {
+		writeNetWorthError(w, err)
+		return
+	}
+	if err := db.SaveValuation(&v); err != nil {
+		writeNetWorthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v)
+}
+
+// DeleteValuation godoc
+// @Summary Hapus satu penilaian
+// @Tags Net Worth
+// @Param id path int true "Valuation ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/net-worth/valuations/{id} [delete]
+func DeleteValuation(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	res := db.DB.Delete(&models.NetWorthValuation{}, id)
+	if res.Error != nil {
+		http.Error(w, "Gagal menghapus penilaian", http.StatusInternalServerError)
+		return
+	}
+	if res.RowsAffected == 0 {
+		http.Error(w, "Penilaian tidak ditemukan", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Penilaian berhasil dihapus"})
+}
+
+// cashByMonth mengembalikan saldo kas kumulatif (transaksi approved) di akhir setiap bulan
+// yang punya transaksi, key YYYY-MM
+func cashByMonth() (map[string]float64, error) {
+	filter := TransactionFilter{}.ForDashboard("")
+	rows, err := monthlyBalanceRows(db.DB, filter, filter.usesSummaries(false))
+	if err != nil {
+		return nil, err
+	}
+	cash := make(map[string]float64, len(rows))
+	for _, r := range rows {
+		cash[r.MonthStart.Format("2006-01")] = float64(r.Saldo)
+	}
+	return cash, nil
+}
+
+// netWorthPoints menghitung posisi di akhir setiap bulan dalam months (akhir bulan berjalan = hari ini).
+// Saldo kas bulan tanpa transaksi memakai saldo bulan sebelumnya.
+func netWorthPoints(items []models.NetWorthItem, cash map[string]float64, today time.Time, months int) []models.NetWorthPoint {
+	first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months - 1), 0)
+
+	// Saldo awal = saldo bulan terakhir sebelum bulan pertama
+	var lastCash float64
+	var latest string
+	for m, saldo := range cash {
+		if m < first.Format("2006-01") && m > latest {
+			latest, lastCash = m, saldo
+		}
+	}
+
+	points := make([]models.NetWorthPoint, 0, months)
+	for i := 0; i < months; i++ {
+		month := first.AddDate(0, i, 0)
+		date := month.AddDate(0, 1, -1)
+		if date.After(today) {
+			date = today
+		}
+		if saldo, ok := cash[month.Format("2006-01")]; ok {
+			lastCash = saldo
+		}
+
+		p := models.NetWorthPoint{Month: month.Format("2006-01"), Date: date.Format("2006-01-02"), Cash: lastCash}
+		for _, item := range items {
+			value, _ := item.ValueAt(date)
+			if item.Kind == models.NetWorthLiability {
+				p.Liabilities += value
+			} else {
+				p.Assets += value
+			}
+		}
+		p.NetWorth = p.Cash + p.Assets - p.Liabilities
+		points = append(points, p)
+	}
+	return points
+}
+
+// GetNetWorth godoc
+// @Summary Net worth dari waktu ke waktu
+// @Description Posisi akhir bulan: saldo kas (saldo kumulatif transaksi approved, sama dengan GetDashboard) + aset - liabilitas. Nilai item memakai penilaian terakhir pada atau sebelum tanggal tersebut. Bulan berjalan dihitung per hari ini.
+// @Tags Net Worth
+// @Produce json
+// @Param months query int false "Jumlah bulan ke belakang termasuk bulan ini (default 12, maksimal 120)"
+// @Success 200 {object} models.NetWorthResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/net-worth [get]
+func GetNetWorth(w http.ResponseWriter, r *http.Request) {
+	months := defaultNetWorthMonths
+	if v := r.URL.Query().Get("months"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "months harus angka positif", http.StatusBadRequest)
+			return
+		}
+		months = min(n, maxNetWorthMonths)
+	}
+
+	items, err := db.ListNetWorthItems()
+	if err != nil {
+		http.Error(w, "Gagal mengambil item net worth", http.StatusInternalServerError)
+		return
+	}
+	cash, err := cashByMonth()
+	if err != nil {
+		http.Error(w, "Gagal menghitung saldo kas", http.StatusInternalServerError)
+		return
+	}
+
+	today := dayOf(time.Now())
+	points := netWorthPoints(items, cash, today, months)
+
+	values := []models.NetWorthItemValue{}
+	for _, item := range items {
+		value, found := item.ValueAt(today)
+		if !found {
+			continue
+		}
+		iv := models.NetWorthItemValue{ID: item.ID, Kind: item.Kind, Category: item.Category, Name: item.Name, Value: value}
+		for _, v := range item.Valuations {
+			if !v.Date.After(today) {
+				iv.ValuedAt = v.Date.Format("2006-01-02")
+			}
+		}
+		values = append(values, iv)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.NetWorthResponse{
+		Current: points[len(points)-1],
+		Points:  points,
+		Items:   values,
+	})
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+	"cash-flow-go/notifications"
+)
+
+// NotificationPreferenceRequest mengganti seluruh preferensi notifikasi user.
+// routes berisi tipe event -> daftar channel (inbox, email, webhook).
+type NotificationPreferenceRequest struct {
+	Email      string              `json:"email" example:"budi@example.com"`
+	WebhookURL string              `json:"webhook_url" example:"https://hooks.example.com/budi"`
+	Routes     map[string][]string `json:"routes"`
+}
+
+// notificationUser memastikan request membawa X-User-ID karena inbox dan preferensi milik user
+func notificationUser(w http.ResponseWriter, r *http.Request) (Identity, bool) {
+	actor := currentIdentity(r)
+	if actor.UserID == "" {
+		http.Error(w, "Header X-User-ID wajib diisi", http.StatusUnauthorized)
+		return actor, false
+	}
+	return actor, true
+}
+
+func writeNotificationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, db.ErrNotificationNotFound), errors.Is(err, db.ErrPreferenceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Gagal memproses notifikasi", http.StatusInternalServerError)
+	}
+}
+
+func preferenceResponse(p models.NotificationPreference) models.NotificationPreferenceResponse {
+	return models.NotificationPreferenceResponse{NotificationPreference: p, Routes: p.RouteMap()}
+}
+
+// GetNotifications godoc
+// @Summary Inbox notifikasi
+// @Description Notifikasi in-app milik user (X-User-ID), terbaru di atas, beserta jumlah yang belum dibaca
+// @Tags Notifications
+// @Produce json
+// @Param X-User-ID header string true "Pemilik inbox"
+// @Param unread query bool false "Hanya yang belum dibaca"
+// @Param limit query int false "Jumlah maksimal (default 50, maksimal 200)"
+// @Success 200 {object} models.NotificationInbox
+// @Failure 401 {object} map[string]string
+// @Router /api/notifications [get]
+func GetNotifications(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit harus angka positif", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, 200)
+	}
+
+	inbox, err := db.ListNotifications(actor.UserID, q.Get("unread") == "true", limit)
+	if err != nil {
+		http.Error(w, "Gagal mengambil notifikasi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(inbox)
+}
+
+// ReadNotification godoc
+// @Summary Tandai notifikasi sudah dibaca
+// @Tags Notifications
+// @Param X-User-ID header string true "Pemilik inbox"
+// @Param id path int true "Notification ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/notifications/{id}/read [post]
+func ReadNotification(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.MarkNotificationRead(actor.UserID, id); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notifikasi ditandai sudah dibaca"})
+}
+
+// ReadAllNotifications godoc
+// @Summary Tandai semua notifikasi sudah dibaca
+// @Tags Notifications
+// @Produce json
+// @Param X-User-ID header string true "Pemilik inbox"
+// @Success 200 {object} map[string]int64
+// @Router /api/notifications/read-all [post]
+func ReadAllNotifications(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	n, err := db.MarkAllNotificationsRead(actor.UserID)
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int64{"updated": n})
+}
+
+// DeleteNotification godoc
+// @Summary Hapus notifikasi dari inbox
+// @Tags Notifications
+// @Param X-User-ID header string true "Pemilik inbox"
+// @Param id path int true "Notification ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/notifications/{id} [delete]
+func DeleteNotification(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if err := db.DeleteNotification(actor.UserID, id); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notifikasi berhasil dihapus"})
+}
+
+// GetNotificationPreferences godoc
+// @Summary Preferensi notifikasi user
+// @Description Jika belum pernah disimpan, routes kosong dan user belum menerima notifikasi apa pun
+// @Tags Notifications
+// @Produce json
+// @Param X-User-ID header string true "User"
+// @Success 200 {object} models.NotificationPreferenceResponse
+// @Router /api/notifications/preferences [get]
+func GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	p, err := db.GetNotificationPreference(actor.UserID)
+	if errors.Is(err, db.ErrPreferenceNotFound) {
+		p, err = &models.NotificationPreference{UserID: actor.UserID, Workspace: actor.Workspace, Role: actor.Role}, nil
+	}
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preferenceResponse(*p))
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Atur event mana dikirim ke channel mana
+// @Description Mengganti seluruh preferensi. Event: bill.upcoming, bill.due, bill.overdue, bill.paid, anomaly.detected, approval.requested (hanya untuk manager). Channel: inbox, email (butuh email), webhook (butuh webhook_url). Workspace dan role ikut disimpan dari header.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User"
+// @Param preferences body NotificationPreferenceRequest true "Preferensi"
+// @Success 200 {object} models.NotificationPreferenceResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/notifications/preferences [put]
+func UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	var req NotificationPreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p := models.NotificationPreference{
+		UserID:    actor.UserID,
+		Workspace: actor.Workspace,
+		Role:      actor.Role,
+		Email:     strings.TrimSpace(req.Email),
+	}
+	if p.Email != "" {
+		addr, err := mail.ParseAddress(p.Email)
+		if err != nil {
+			http.Error(w, "email tidak valid", http.StatusBadRequest)
+			return
+		}
+		p.Email = addr.Address
+	}
+	if v := strings.TrimSpace(req.WebhookURL); v != "" {
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			http.Error(w, "webhook_url harus URL http atau https yang valid", http.StatusBadRequest)
+			return
+		}
+		p.WebhookURL = u.String()
+	}
+
+	events := make([]string, 0, len(req.Routes))
+	for event := range req.Routes {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		seen := map[string]bool{}
+		for _, channel := range req.Routes[event] {
+			if seen[channel] {
+				continue
+			}
+			seen[channel] = true
+			p.Routes = append(p.Routes, models.NotificationRoute{Event: event, Channel: channel})
+		}
+	}
+	if err := notifications.Check(p); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := db.SaveNotificationPreference(&p); err != nil {
+		http.Error(w, "Gagal menyimpan preferensi notifikasi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preferenceResponse(p))
+}
+
+// TestNotification godoc
+// @Summary Kirim notifikasi uji
+// @Description Mengirim pesan uji ke setiap channel yang dipakai preferensi user, misal untuk mengecek SMTP ke mail catcher lokal. Hasil per channel berisi "ok" atau pesan error.
+// @Tags Notifications
+// @Produce json
+// @Param X-User-ID header string true "User"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/notifications/test [post]
+func TestNotification(w http.ResponseWriter, r *http.Request) {
+	actor, ok := notificationUser(w, r)
+	if !ok {
+		return
+	}
+	p, err := db.GetNotificationPreference(actor.UserID)
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(notifications.SendTest(*p))
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+
+	"github.com/gorilla/mux"
+)
+
+// RecurringRequest adalah body untuk membuat recurring transaction.
+// Tanggal memakai format YYYY-MM-DD.
+type RecurringRequest struct {
+	Type        string  `json:"type" example:"pengeluaran"`
+	Amount      float64 `json:"amount" example:"1500000"`
+	Description string  `json:"description" example:"Sewa kos"`
+	Category    string  `json:"category" example:"tempat tinggal"`
+	Account     string  `json:"account" example:"bca"`
+	Payee       string  `json:"payee" example:"Bu Kos"`
+	Frequency   string  `json:"frequency" example:"monthly"`
+	Interval    int     `json:"interval" example:"1"`
+	StartDate   string  `json:"start_date" example:"2025-08-01"`
+	EndDate     string  `json:"end_date,omitempty" example:"2026-07-01"`
+}
+
+// pathID membaca {id} dari URL
+func pathID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID tidak valid", http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseDate membaca tanggal YYYY-MM-DD sebagai tanggal lokal (00:00 UTC)
+func parseDate(v string) (time.Time, error) {
+	return time.Parse("2006-01-02", v)
+}
+
+func (req RecurringRequest) toModel() (models.RecurringTransaction, error) {
+	rt := models.RecurringTransaction{
+		Type:        req.Type,
+		Amount:      req.Amount,
+		Description: req.Description,
+		Category:    req.Category,
+		Account:     req.Account,
+		Payee:       req.Payee,
+		Frequency:   req.Frequency,
+		Interval:    max(req.Interval, 1),
+		IsActive:    true,
+	}
+
+	if rt.Type != "pemasukan" && rt.Type != "pengeluaran" {
+		return rt, errors.New("type harus pemasukan atau pengeluaran")
+	}
+	if rt.Amount <= 0 {
+		return rt, errors.New("amount harus lebih dari 0")
+	}
+	if !models.IsValidFrequency(rt.Frequency) {
+		return rt, errors.New("frequency harus daily, weekly, monthly atau yearly")
+	}
+
+	var err error
+	if rt.StartDate, err = parseDate(req.StartDate); err != nil {
+		return rt, errors.New("start_date wajib diisi dengan format YYYY-MM-DD")
+	}
+	if req.EndDate != "" {
+		end, err := parseDate(req.EndDate)
+		if err != nil {
+			return rt, errors.New("end_date harus format YYYY-MM-DD")
+		}
+		if end.Before(rt.StartDate) {
+			return rt, errors.New("end_date harus setelah start_date")
+		}
+		rt.EndDate = &end
+	}
+	return rt, nil
+}
+
+// CreateRecurringTransaction godoc
+// @Summary Tambah recurring transaction
+// @Description Template transaksi berulang (gaji, sewa, langganan) yang dipakai forecast
+// @Tags Recurring
+// @Accept json
+// @Produce json
+// @Param recurring body RecurringRequest true "Recurring transaction"
+// @Success 201 {object} models.RecurringTransaction
+// @Failure 400 {object} map[string]string
+// @Router /api/recurring [post]
+func CreateRecurringTransaction(w http.ResponseWriter, r *http.Request) {
+	var req RecurringRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rt, err := req.toModel()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rt.CreatedBy = currentIdentity(r).UserID
+
+	if err := db.DB.Create(&rt).Error; err != nil {
+		http.Error(w, "Gagal menyimpan recurring transaction", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rt)
+}
+
+// GetRecurringTransactions godoc
+// @Summary Daftar recurring transaction
+// @Tags Recurring
+// @Produce json
+// @Success 200 {array} models.RecurringTransaction
+// @Router /api/recurring [get]
+func GetRecurringTransactions(w http.ResponseWriter, r *http.Request) {
+	var list []models.RecurringTransaction
+	if err := db.DB.Order("start_date ASC").Find(&list).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// DeleteRecurringTransaction godoc
+// @Summary Hapus recurring transaction
+// @Description Transaksi yang sudah tercatat tidak berubah dan tetap tidak dihitung sebagai pengeluaran variabel
+// @Tags Recurring
+// @Param id path int true "Recurring ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/recurring/{id} [delete]
+func DeleteRecurringTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	res := db.DB.Delete(&models.RecurringTransaction{}, id)
+	if res.Error != nil {
+		http.Error(w, "Gagal menghapus recurring transaction", http.StatusInternalServerError)
+		return
+	}
+	if res.RowsAffected == 0 {
+		http.Error(w, "Recurring transaction tidak ditemukan", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Recurring transaction berhasil dihapus"})
+}
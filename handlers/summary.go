@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"time"
+
+	db "cash-flow-go/database"
+
+	"gorm.io/gorm"
+)
+
+// refreshSummaries menghitung ulang rollup harian untuk tanggal-tanggal transaksi yang berubah.
+// Panggil di dalam DB transaction yang sama dengan perubahannya.
+func refreshSummaries(conn *gorm.DB, times ...time.Time) error {
+	days := make([]time.Time, len(times))
+	for i, t := range times {
+		days[i] = dayOf(t)
+	}
+	return db.RefreshDailySummaries(conn, appZone, days...)
+}
+
+// Timezone adalah zona waktu aplikasi (APP_TIMEZONE), dipakai juga oleh command rebuild rollup
+func Timezone() *time.Location {
+	return appZone
+}
@@ -1,8 +1,67 @@
 package handlers
 
-import "time"
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
 
+// DefaultTimezone dipakai jika APP_TIMEZONE tidak di-set
+const DefaultTimezone = "Asia/Jakarta"
+
+// appZone adalah zona waktu aplikasi (APP_TIMEZONE, default WIB). Semua pembagian
+// periode (hari, bulan) di filter dan chart memakai zona ini.
+var appZone = loadAppZone(os.Getenv("APP_TIMEZONE"), time.LoadLocation)
+
+// loadAppZone memuat zona name lewat load. Nama zona ikut disisipkan ke SQL (AT TIME ZONE),
+// jadi "Local" yang tidak dikenal Postgres ditolak. Jika tzdata tidak tersedia sama sekali
+// dipakai UTC+7 tetap, dinamai Asia/Jakarta supaya Postgres tetap mengenali namanya.
+func loadAppZone(name string, load func(string) (*time.Location, error)) *time.Location {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := load(name)
+	if err == nil && name == "Local" {
+		err = fmt.Errorf("zona Local tidak bisa dipakai di SQL, isi nama IANA seperti %s", DefaultTimezone)
+	}
+	if err == nil {
+		return loc
+	}
+	log.Printf("APP_TIMEZONE %q tidak valid (%v), pakai %s", name, err, DefaultTimezone)
+	if loc, err = load(DefaultTimezone); err == nil {
+		return loc
+	}
+	log.Printf("tzdata tidak tersedia (%v), pakai UTC+7", err)
+	return time.FixedZone(DefaultTimezone, 7*60*60)
+}
+
+// ToLocal memformat waktu dalam zona aplikasi
+func ToLocal(t time.Time) string {
+	return t.In(appZone).Format("2006-01-02 15:04:05")
+}
+
+// ToWIB memformat waktu dalam zona aplikasi.
+//
+// Deprecated: zona aplikasi tidak selalu WIB lagi, pakai ToLocal.
 func ToWIB(t time.Time) string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-	return t.In(loc).Format("2006-01-02 15:04:05")
+	return ToLocal(t)
+}
+
+// dayOf mengembalikan tanggal (jam 00:00) di zona aplikasi untuk t
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.In(appZone).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// localTimeSQL adalah transaction_at sebagai waktu lokal zona aplikasi.
+// Nama zona aman disisipkan langsung karena sudah lolos time.LoadLocation.
+func localTimeSQL() string {
+	return fmt.Sprintf("(transactions.transaction_at AT TIME ZONE '%s')", appZone.String())
+}
+
+// periodSQL adalah ekspresi SQL awal periode (day, week, month, year) dari transaction_at
+// di zona aplikasi. Semua chart memakai ini supaya definisi "bulan" selalu sama.
+func periodSQL(unit string) string {
+	return fmt.Sprintf("date_trunc('%s', %s)", unit, localTimeSQL())
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadAppZone(t *testing.T) {
+	noTZData := func(string) (*time.Location, error) { return nil, errors.New("unknown time zone") }
+	tests := []struct {
+		name     string
+		env      string
+		load     func(string) (*time.Location, error)
+		wantName string
+	}{
+		{"default", "", time.LoadLocation, "Asia/Jakarta"},
+		{"valid", "Asia/Makassar", time.LoadLocation, "Asia/Makassar"},
+		{"utc", "UTC", time.LoadLocation, "UTC"},
+		{"invalid falls back to default", "Mars/Olympus", time.LoadLocation, "Asia/Jakarta"},
+		{"local rejected", "Local", time.LoadLocation, "Asia/Jakarta"},
+		{"no tzdata", "Asia/Makassar", noTZData, "Asia/Jakarta"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := loadAppZone(tt.env, tt.load)
+			if loc == nil {
+				t.Fatal("loadAppZone returned nil")
+			}
+			if loc.String() != tt.wantName {
+				t.Errorf("zone = %s, want %s", loc, tt.wantName)
+			}
+		})
+	}
+
+	// Tanpa tzdata tetap UTC+7
+	loc := loadAppZone("", noTZData)
+	if _, offset := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone(); offset != 7*60*60 {
+		t.Errorf("fallback offset = %d, want %d", offset, 7*60*60)
+	}
+}
+
+func TestLocalTimeSQLUsesZoneName(t *testing.T) {
+	prev := appZone
+	defer func() { appZone = prev }()
+
+	appZone = loadAppZone("Local", time.LoadLocation)
+	if got := localTimeSQL(); !strings.Contains(got, "AT TIME ZONE 'Asia/Jakarta'") {
+		t.Errorf("localTimeSQL() = %s", got)
+	}
+}
+
+func TestToWIB(t *testing.T) {
+	ts := time.Date(2025, 9, 1, 17, 30, 0, 0, time.UTC)
+	if ToWIB(ts) != ToLocal(ts) {
+		t.Errorf("ToWIB(%v) = %s, want %s", ts, ToWIB(ts), ToLocal(ts))
+	}
+}
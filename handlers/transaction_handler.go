@@ -7,6 +7,7 @@ import (
 	"time"
 
 	db "cash-flow-go/database"
+	"cash-flow-go/events"
 	"cash-flow-go/models"
 
 	"github.com/gorilla/mux"
@@ -34,6 +35,17 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Frontend boleh simpan sebagai draft dulu, selain itu status ditentukan threshold
+	if tx.Status != models.StatusDraft {
+		tx.Status = initialStatus(tx)
+	}
+	tx.CreatedBy = currentIdentity(r).UserID
+	tx.ApprovedBy = ""
+	tx.ApprovalComment = ""
+	tx.DecidedAt = nil
+	tx.DebtID = nil // dihubungkan lewat /api/debts supaya sisa hutang piutang tetap konsisten
+	tx.InstallmentID = nil
+
 	// Gunakan waktu sekarang jika CreatedAt tidak dikirim dari frontend
 	if tx.CreatedAt.IsZero() {
 		tx.TransactionAt = time.Now()
@@ -43,7 +55,30 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 
 	tx.CreatedAt = time.Now()
 
-	db.DB.Create(&tx)
+	err := db.DB.Transaction(func(dbtx *gorm.DB) error {
+		if err := dbtx.Create(&tx).Error; err != nil {
+			return err
+		}
+		if err := recordApproval(dbtx, tx.ID, "", tx.Status, tx.CreatedBy, ""); err != nil {
+			return err
+		}
+		if tx.Status != models.StatusApproved {
+			return nil
+		}
+		return refreshSummaries(dbtx, tx.TransactionAt)
+	})
+	if err != nil {
+		http.Error(w, "Gagal menyimpan transaksi", http.StatusInternalServerError)
+		return
+	}
+	if tx.Status == models.StatusApproved {
+		invalidateDashboardCache(tx.TransactionAt)
+	}
+	publishTransaction(events.TransactionCreated, currentIdentity(r), tx, tx.Status == models.StatusApproved)
+	go detectAnomalies(tx)
+	go matchPayment(tx)
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(tx)
 }
@@ -55,6 +90,16 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 // @Param page query int false "Page number (default 1)"
 // @Param limit query int false "Limit per page (default 10)"
 // @Param type query string false "Filter by type (pemasukan/pengeluaran)"
+// @Param status query string false "Filter by status (draft/submitted/approved/rejected)"
+// @Param category query string false "Filter by category"
+// @Param account query string false "Filter by account"
+// @Param payee query string false "Filter by payee"
+// @Param period query string false "Preset periode (this_month/last_month/last_30_days/ytd/custom)"
+// @Param from query string false "Tanggal mulai (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param to query string false "Tanggal akhir, inklusif (YYYY-MM-DD zona APP_TIMEZONE atau RFC3339)"
+// @Param description query string false "Cari di deskripsi"
+// @Param min_amount query number false "Amount minimal"
+// @Param max_amount query number false "Amount maksimal"
 // @Success 200 {array} models.TransactionResponse
 // @Router /api/transactions [get]
 // GetTransactions handles fetching transactions with optional filters and pagination
@@ -78,55 +123,18 @@ func GetTransactions(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Filters
-	txType := query.Get("type")
-	category := query.Get("category")
-	startDate := query.Get("start_date")
-	endDate := query.Get("end_date")
-	description := query.Get("description")
-	minAmount := query.Get("min_amount")
-	maxAmount := query.Get("max_amount")
+	// Filters (sama dengan yang dipakai dashboard)
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Builder utama untuk data transaksi
-	queryBuilder := db.DB.Model(&models.Transaction{})
+	queryBuilder := filter.Apply(db.DB.Model(&models.Transaction{}))
 	// Builder terpisah untuk count dan sum
-	countBuilder := db.DB.Model(&models.Transaction{})
-	sumBuilder := db.DB.Model(&models.Transaction{})
-
-	// Fungsi untuk apply filter ke builder
-	applyFilters := func(b *gorm.DB) *gorm.DB {
-		if txType == "pemasukan" || txType == "pengeluaran" {
-			b = b.Where("type = ?", txType)
-		}
-		if category != "" {
-			b = b.Where("category = ?", category)
-		}
-		if startDate != "" {
-			b = b.Where("transaction_at >= ?", startDate)
-		}
-		if endDate != "" {
-			b = b.Where("transaction_at <= ?", endDate)
-		}
-		if description != "" {
-			b = b.Where("description LIKE ?", "%"+description+"%")
-		}
-		if minAmount != "" {
-			if min, err := strconv.ParseFloat(minAmount, 64); err == nil {
-				b = b.Where("amount >= ?", min)
-			}
-		}
-		if maxAmount != "" {
-			if max, err := strconv.ParseFloat(maxAmount, 64); err == nil {
-				b = b.Where("amount <= ?", max)
-			}
-		}
-		return b
-	}
-
-	// Apply filter ke semua builder
-	queryBuilder = applyFilters(queryBuilder)
-	countBuilder = applyFilters(countBuilder)
-	sumBuilder = applyFilters(sumBuilder)
+	countBuilder := filter.Apply(db.DB.Model(&models.Transaction{}))
+	sumBuilder := filter.Apply(db.DB.Model(&models.Transaction{}))
 
 	// Hitung total count dan total amount
 	var totalCount int64
@@ -142,18 +150,7 @@ func GetTransactions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var txResponses []models.TransactionResponse
-	for _, tx := range txs {
-		txResponses = append(txResponses, models.TransactionResponse{
-			ID:            tx.ID,
-			Type:          tx.Type,
-			Category:      tx.Category,
-			Description:   tx.Description,
-			Amount:        tx.Amount,
-			TransactionAt: ToWIB(tx.CreatedAt),
-			CreatedAt:     ToWIB(tx.CreatedAt),
-		})
-	}
+	txResponses := toTransactionResponses(txs)
 
 	// Response
 	response := map[string]interface{}{
@@ -190,18 +187,7 @@ func GetTop5Transactions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Format ke response DTO
-	var txResponses []models.TransactionResponse
-	for _, tx := range txs {
-		txResponses = append(txResponses, models.TransactionResponse{
-			ID:            tx.ID,
-			Type:          tx.Type,
-			Category:      tx.Category,
-			Description:   tx.Description,
-			Amount:        tx.Amount,
-			TransactionAt: ToWIB(tx.CreatedAt),
-			CreatedAt:     ToWIB(tx.CreatedAt),
-		})
-	}
+	txResponses := toTransactionResponses(txs)
 
 	// Response JSON
 	response := map[string]interface{}{
@@ -234,11 +220,63 @@ func DeleteTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := db.DB.Delete(&tx).Error; err != nil {
+	var attachmentKeys []string
+	err = db.DB.Transaction(func(dbtx *gorm.DB) error {
+		if err := dbtx.Delete(&tx).Error; err != nil {
+			return err
+		}
+		keys, err := deleteTransactionAttachments(dbtx, tx.ID)
+		if err != nil {
+			return err
+		}
+		attachmentKeys = keys
+		if err := db.UnlinkDebtTransaction(dbtx, tx.ID); err != nil {
+			return err
+		}
+		if err := db.UnlinkInstallmentTransaction(dbtx, tx.ID); err != nil {
+			return err
+		}
+		if err := db.UnlinkBillTransaction(dbtx, tx.ID); err != nil {
+			return err
+		}
+		if tx.Status != models.StatusApproved {
+			return nil
+		}
+		return refreshSummaries(dbtx, tx.TransactionAt)
+	})
+	if err != nil {
 		http.Error(w, "Gagal menghapus transaksi", http.StatusInternalServerError)
 		return
 	}
+	removeAttachmentFiles(attachmentKeys)
+	if tx.Status == models.StatusApproved {
+		invalidateDashboardCache(tx.TransactionAt)
+	}
+	publishTransaction(events.TransactionDeleted, currentIdentity(r), tx, tx.Status == models.StatusApproved)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Transaksi berhasil dihapus"})
 }
+
+// toTransactionResponses mengubah model transaksi ke response DTO (waktu dalam zona aplikasi)
+func toTransactionResponses(txs []models.Transaction) []models.TransactionResponse {
+	var txResponses []models.TransactionResponse
+	for _, tx := range txs {
+		txResponses = append(txResponses, models.TransactionResponse{
+			ID:            tx.ID,
+			Type:          tx.Type,
+			Category:      tx.Category,
+			Description:   tx.Description,
+			Amount:        tx.Amount,
+			Account:       tx.Account,
+			Payee:         tx.Payee,
+			Status:        tx.Status,
+			CreatedBy:     tx.CreatedBy,
+			TransactionAt: ToLocal(tx.TransactionAt),
+			CreatedAt:     ToLocal(tx.CreatedAt),
+			DebtID:        tx.DebtID,
+			InstallmentID: tx.InstallmentID,
+		})
+	}
+	return txResponses
+}
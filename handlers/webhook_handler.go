@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+	"cash-flow-go/webhooks"
+)
+
+// WebhookRequest adalah body untuk membuat / mengubah subscription webhook.
+// Saat update semua field opsional.
+type WebhookRequest struct {
+	URL         *string  `json:"url" example:"https://example.com/hooks/cashflow"`
+	Events      []string `json:"events" example:"transaction.*,campaign.created"`
+	Description *string  `json:"description" example:"Bot Discord keluarga"`
+	IsActive    *bool    `json:"is_active" example:"true"`
+}
+
+// WebhookCreated adalah response pembuatan webhook; secret hanya ditampilkan sekali di sini
+type WebhookCreated struct {
+	models.WebhookSubscription
+	Secret string `json:"secret" example:"whsec_3f9c..."`
+}
+
+func (req WebhookRequest) apply(s *models.WebhookSubscription) error {
+	if req.URL != nil {
+		u, err := url.Parse(strings.TrimSpace(*req.URL))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("url harus URL http atau https yang valid")
+		}
+		s.URL = u.String()
+	}
+	if req.Events != nil {
+		if len(req.Events) == 0 {
+			return errors.New("events minimal berisi satu tipe event")
+		}
+		for _, p := range req.Events {
+			if !webhooks.ValidPattern(p) {
+				return errors.New("event tidak dikenal: " + p)
+			}
+		}
+		s.Events = req.Events
+	}
+	if req.Description != nil {
+		s.Description = *req.Description
+	}
+	if req.IsActive != nil {
+		s.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// requireWebhookManager menolak request dari selain manager dan request tanpa workspace,
+// karena subscription dan log pengirimannya selalu dibatasi ke satu workspace
+func requireWebhookManager(w http.ResponseWriter, r *http.Request) (Identity, bool) {
+	actor := currentIdentity(r)
+	if !actor.IsManager() {
+		http.Error(w, "Hanya manager yang bisa mengatur webhook", http.StatusForbidden)
+		return actor, false
+	}
+	if actor.Workspace == "" {
+		http.Error(w, "Header X-Workspace-ID wajib diisi untuk mengatur webhook", http.StatusBadRequest)
+		return actor, false
+	}
+	return actor, true
+}
+
+// webhookFor mengambil subscription dan memastikan milik workspace manager yang mengakses
+func webhookFor(w http.ResponseWriter, actor Identity, id uint) (*models.WebhookSubscription, bool) {
+	s, err := db.GetWebhook(id)
+	if err == nil && s.Workspace != actor.Workspace {
+		err = db.ErrWebhookNotFound
+	}
+	if err != nil {
+		writeWebhookError(w, err)
+		return nil, false
+	}
+	return s, true
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	if errors.Is(err, db.ErrWebhookNotFound) || errors.Is(err, db.ErrDeliveryNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, "Gagal menyimpan webhook", http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// CreateWebhook godoc
+// @Summary Tambah subscription webhook
+// @Description Event dikirim sebagai POST JSON {id, type, user_id, workspace, data, at}. Header X-CashFlow-Signature berisi "t=<unix>,v1=<hex>" dengan v1 = HMAC-SHA256(secret, "<t>.<body>"). Secret hanya dikembalikan sekali di response ini. Filter events menerima tipe event, "<grup>.*" (misal "transaction.*") atau "*". Subscription dibatasi ke workspace manager; header X-Workspace-ID wajib.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body WebhookRequest true "Subscription"
+// @Success 201 {object} WebhookCreated
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/webhooks [post]
+func CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.URL == nil || req.Events == nil {
+		http.Error(w, "url dan events wajib diisi", http.StatusBadRequest)
+		return
+	}
+
+	s := models.WebhookSubscription{IsActive: true, Workspace: actor.Workspace, CreatedBy: actor.UserID}
+	if err := req.apply(&s); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		http.Error(w, "Gagal membuat secret", http.StatusInternalServerError)
+		return
+	}
+	s.Secret = secret
+
+	if err := db.DB.Create(&s).Error; err != nil {
+		http.Error(w, "Gagal menyimpan webhook", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, WebhookCreated{WebhookSubscription: s, Secret: secret})
+}
+
+// ListWebhooks godoc
+// @Summary Daftar subscription webhook
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 403 {object} map[string]string
+// @Router /api/webhooks [get]
+func ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := db.ListWebhooks(actor.Workspace)
+	if err != nil {
+		http.Error(w, "Gagal mengambil webhook", http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = []models.WebhookSubscription{}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// GetWebhook godoc
+// @Summary Detail subscription webhook
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/{id} [get]
+func GetWebhook(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s, ok := webhookFor(w, actor, id)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+// UpdateWebhook godoc
+// @Summary Ubah URL, filter event atau status aktif webhook
+// @Description Semua field opsional. Pengiriman yang masih antre untuk webhook nonaktif langsung masuk dead-letter.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body WebhookRequest true "Perubahan"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/{id} [put]
+func UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := webhookFor(w, actor, id); !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var invalid error
+	s, err := db.UpdateWebhook(id, func(s *models.WebhookSubscription) error {
+		invalid = req.apply(s)
+		return invalid
+	})
+	if invalid != nil {
+		http.Error(w, invalid.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+// DeleteWebhook godoc
+// @Summary Hapus subscription webhook
+// @Description Log pengiriman webhook ini ikut dihapus
+// @Tags Webhooks
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/{id} [delete]
+func DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := webhookFor(w, actor, id); !ok {
+		return
+	}
+
+	if err := db.DeleteWebhook(id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Webhook berhasil dihapus"})
+}
+
+// listDeliveries membaca query status dan limit lalu menulis log pengiriman
+func listDeliveries(w http.ResponseWriter, r *http.Request, subscriptionID uint, workspace, status string) {
+	if v := r.URL.Query().Get("status"); v != "" && status == "" {
+		if v != models.DeliveryPending && v != models.DeliverySucceeded && v != models.DeliveryDead {
+			http.Error(w, "status harus pending, succeeded atau dead", http.StatusBadRequest)
+			return
+		}
+		status = v
+	}
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit harus angka positif", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, 200)
+	}
+
+	list, err := db.ListDeliveries(subscriptionID, workspace, status, limit)
+	if err != nil {
+		http.Error(w, "Gagal mengambil log pengiriman", http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = []models.WebhookDelivery{}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Log pengiriman satu webhook
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "pending, succeeded atau dead"
+// @Param limit query int false "Jumlah maksimal (default 50, maksimal 200)"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/{id}/deliveries [get]
+func GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := webhookFor(w, actor, id); !ok {
+		return
+	}
+	listDeliveries(w, r, id, "", "")
+}
+
+// GetDeadLetters godoc
+// @Summary Dead-letter webhook
+// @Description Pengiriman yang gagal sampai batas retry (8 percobaan dengan exponential backoff) atau webhook-nya dinonaktifkan
+// @Tags Webhooks
+// @Produce json
+// @Param limit query int false "Jumlah maksimal (default 50, maksimal 200)"
+// @Success 200 {array} models.WebhookDelivery
+// @Router /api/webhooks/dead-letters [get]
+func GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return
+	}
+	listDeliveries(w, r, 0, actor.Workspace, models.DeliveryDead)
+}
+
+// deliveryFor mengambil pengiriman dan memastikan webhook-nya milik workspace manager
+func deliveryFor(w http.ResponseWriter, r *http.Request) (*models.WebhookDelivery, bool) {
+	actor, ok := requireWebhookManager(w, r)
+	if !ok {
+		return nil, false
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	d, err := db.GetDelivery(id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return nil, false
+	}
+	if _, ok := webhookFor(w, actor, d.SubscriptionID); !ok {
+		return nil, false
+	}
+	return d, true
+}
+
+// GetWebhookDelivery godoc
+// @Summary Detail pengiriman webhook
+// @Description Termasuk payload dan log setiap percobaan
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/deliveries/{id} [get]
+func GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	d, ok := deliveryFor(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+// RedeliverWebhook godoc
+// @Summary Kirim ulang pengiriman webhook
+// @Description Payload yang sama dikirim ulang (header X-CashFlow-Delivery tetap) dengan jatah retry baru
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/deliveries/{id}/redeliver [post]
+func RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	d, ok := deliveryFor(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := db.RedeliverWebhook(d.ID, time.Now())
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	webhooks.Kick()
+	writeJSON(w, http.StatusAccepted, d)
+}
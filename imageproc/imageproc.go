@@ -0,0 +1,159 @@
+// Package imageproc memvalidasi dan memproses gambar upload (campaign):
+// sniffing content type, batas dimensi, strip metadata EXIF, dan pembuatan
+// varian responsif. Semuanya pure Go.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+var (
+	ErrUnsupportedType = errors.New("hanya gambar JPEG, PNG dan WebP yang diizinkan")
+	ErrTooLarge        = errors.New("dimensi gambar melebihi batas")
+)
+
+// Batas dimensi gambar yang diterima
+const (
+	MaxWidth  = 4096
+	MaxHeight = 4096
+)
+
+const jpegQuality = 85
+
+// Variant adalah ukuran responsif yang dibuat dari gambar asli
+type Variant struct {
+	Name  string
+	Width int
+}
+
+var Variants = []Variant{
+	{Name: "thumbnail", Width: 320},
+	{Name: "mobile", Width: 768},
+	{Name: "desktop", Width: 1440},
+}
+
+// Encoded adalah hasil encode satu gambar yang siap disimpan
+type Encoded struct {
+	Data        []byte
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+}
+
+// Result berisi gambar asli (sudah bersih dari metadata) dan varian-variannya
+type Result struct {
+	Original Encoded
+	Variants map[string]Encoded
+}
+
+// Process membaca gambar, memvalidasi tipe dan dimensi, lalu menghasilkan
+// gambar asli tanpa EXIF beserta varian thumbnail / mobile / desktop.
+func Process(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(data)
+	var decode func(io.Reader) (image.Image, error)
+	var decodeConfig func(io.Reader) (image.Config, error)
+	switch contentType {
+	case "image/jpeg":
+		decode, decodeConfig = jpeg.Decode, jpeg.DecodeConfig
+	case "image/png":
+		decode, decodeConfig = png.Decode, png.DecodeConfig
+	case "image/webp":
+		decode, decodeConfig = webp.Decode, webp.DecodeConfig
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	// Cek dimensi dari header dulu supaya gambar raksasa tidak sempat di-decode penuh
+	cfg, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gambar rusak: %w", err)
+	}
+	if cfg.Width > MaxWidth || cfg.Height > MaxHeight {
+		return nil, fmt.Errorf("%w (%dx%d, maksimal %dx%d)", ErrTooLarge, cfg.Width, cfg.Height, MaxWidth, MaxHeight)
+	}
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gambar rusak: %w", err)
+	}
+
+	res := &Result{Variants: map[string]Encoded{}}
+
+	switch contentType {
+	case "image/jpeg":
+		// Orientasi EXIF diterapkan ke pixel sebelum metadata dibuang
+		img = applyOrientation(img, jpegOrientation(data))
+		res.Original, err = encode(img, contentType)
+	case "image/png":
+		res.Original, err = encode(img, contentType)
+	case "image/webp":
+		// Tidak ada encoder WebP pure Go, jadi chunk metadata dibuang langsung dari container
+		res.Original, err = stripWebPMetadata(data, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Varian memakai PNG jika gambar punya transparansi, selain itu JPEG
+	variantType := "image/jpeg"
+	if o, ok := img.(interface{ Opaque() bool }); ok && !o.Opaque() {
+		variantType = "image/png"
+	}
+	for _, v := range Variants {
+		enc, err := encode(resize(img, v.Width), variantType)
+		if err != nil {
+			return nil, err
+		}
+		res.Variants[v.Name] = enc
+	}
+	return res, nil
+}
+
+// resize memperkecil gambar ke lebar tertentu dengan rasio tetap.
+// Gambar yang sudah lebih kecil tidak diperbesar.
+func resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= width {
+		return img
+	}
+	height := max(b.Dy()*width/b.Dx(), 1)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+func encode(img image.Image, contentType string) (Encoded, error) {
+	var buf bytes.Buffer
+	enc := Encoded{ContentType: contentType, Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+
+	var err error
+	switch contentType {
+	case "image/png":
+		enc.Ext = ".png"
+		err = png.Encode(&buf, img)
+	default:
+		enc.ContentType, enc.Ext = "image/jpeg", ".jpg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return Encoded{}, err
+	}
+	enc.Data = buf.Bytes()
+	return enc, nil
+}
@@ -0,0 +1,258 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// exifTIFF membuat blok TIFF dengan satu IFD berisi tag-tag (tag, nilai SHORT)
+func exifTIFF(order binary.ByteOrder, tags ...[2]uint16) []byte {
+	var b bytes.Buffer
+	if order == binary.LittleEndian {
+		b.WriteString("II")
+	} else {
+		b.WriteString("MM")
+	}
+	binary.Write(&b, order, uint16(42))
+	binary.Write(&b, order, uint32(8))
+	binary.Write(&b, order, uint16(len(tags)))
+	for _, tag := range tags {
+		binary.Write(&b, order, tag[0])
+		binary.Write(&b, order, uint16(3)) // SHORT
+		binary.Write(&b, order, uint32(1))
+		binary.Write(&b, order, tag[1])
+		binary.Write(&b, order, uint16(0))
+	}
+	binary.Write(&b, order, uint32(0)) // tidak ada IFD berikutnya
+	return b.Bytes()
+}
+
+// segment membuat segmen JPEG marker + panjang + isi
+func segment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+// withSegments menyisipkan segmen setelah SOI sebuah JPEG
+func withSegments(jpg []byte, segs ...[]byte) []byte {
+	out := append([]byte(nil), jpg[:2]...)
+	for _, s := range segs {
+		out = append(out, s...)
+	}
+	return append(out, jpg[2:]...)
+}
+
+func sampleJPEG(t testing.TB) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func exifSegment(tiff []byte) []byte {
+	return segment(0xE1, append([]byte("Exif\x00\x00"), tiff...))
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	jpg := sampleJPEG(t)
+	truncated := exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x0112, 6}))
+	truncated = truncated[:len(truncated)-6]
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"no exif", jpg, 1},
+		{"big endian rotate 90", withSegments(jpg, exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x0112, 6}))), 6},
+		{"little endian rotate 180", withSegments(jpg, exifSegment(exifTIFF(binary.LittleEndian, [2]uint16{0x0112, 3}))), 3},
+		{"orientation after other tags", withSegments(jpg, exifSegment(exifTIFF(binary.LittleEndian,
+			[2]uint16{0x010F, 1}, [2]uint16{0x0110, 2}, [2]uint16{0x0112, 8}))), 8},
+		{"after JFIF APP0", withSegments(jpg, segment(0xE0, []byte("JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00")),
+			exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x0112, 5}))), 5},
+		{"XMP APP1 is skipped", withSegments(jpg, segment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00<x/>")),
+			exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x0112, 2}))), 2},
+		{"out of range value", withSegments(jpg, exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x0112, 9}))), 1},
+		{"no orientation tag", withSegments(jpg, exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x010F, 6}))), 1},
+		{"segment longer than file", withSegments(jpg[:2], truncated), 1},
+		{"not a jpeg", []byte("\x89PNG\r\n\x1a\n"), 1},
+		{"empty", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Errorf("jpegOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTIFFOrientation(t *testing.T) {
+	valid := exifTIFF(binary.LittleEndian, [2]uint16{0x0112, 6})
+	badOffset := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint32(badOffset[4:], 0xFFFFFFF0)
+	manyEntries := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint16(manyEntries[8:], 500)
+	binary.LittleEndian.PutUint16(manyEntries[10:], 0x0100) // tag pertama bukan orientasi
+
+	tests := []struct {
+		name string
+		tiff []byte
+		want int
+	}{
+		{"valid", valid, 6},
+		{"short", valid[:7], 1},
+		{"unknown byte order", append([]byte("XX"), valid[2:]...), 1},
+		{"IFD offset beyond data", badOffset, 1},
+		{"entry count beyond data", manyEntries, 1},
+		{"truncated entry", valid[:len(valid)-8], 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tiffOrientation(tt.tiff); got != tt.want {
+				t.Errorf("tiffOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 2x1: merah di kiri, biru di kanan
+	red, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 0, 255, 255}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		first       color.RGBA // pixel (0, 0) hasil
+	}{
+		{1, image.Pt(2, 1), red},
+		{2, image.Pt(2, 1), blue},
+		{3, image.Pt(2, 1), blue},
+		{6, image.Pt(1, 2), red},
+		{8, image.Pt(1, 2), blue},
+	}
+	for _, tt := range tests {
+		got := applyOrientation(src, tt.orientation)
+		if got.Bounds().Size() != tt.size {
+			t.Errorf("orientation %d: size = %v, want %v", tt.orientation, got.Bounds().Size(), tt.size)
+			continue
+		}
+		if c := color.RGBAModel.Convert(got.At(0, 0)); c != tt.first {
+			t.Errorf("orientation %d: pixel (0,0) = %v, want %v", tt.orientation, c, tt.first)
+		}
+	}
+}
+
+// chunk membuat chunk RIFF dengan padding ke ukuran genap
+func chunk(fourCC string, payload []byte) []byte {
+	c := append([]byte(fourCC), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(c[4:], uint32(len(payload)))
+	c = append(c, payload...)
+	if len(payload)%2 == 1 {
+		c = append(c, 0)
+	}
+	return c
+}
+
+func riffWebP(chunks ...[]byte) []byte {
+	body := []byte("WEBP")
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	out := append([]byte("RIFF"), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(body)))
+	return append(out, body...)
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	vp8x := []byte{0x08 | 0x04 | 0x10, 0, 0, 0, 3, 0, 0, 1, 0, 0} // EXIF, XMP, alpha; 4x2
+	bitstream := chunk("VP8L", []byte{0x2f, 1, 2, 3, 4})
+	cfg := image.Config{Width: 4, Height: 2}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "exif and xmp removed, flags cleared",
+			data: riffWebP(chunk("VP8X", vp8x), bitstream, chunk("EXIF", []byte("Exif\x00\x00MM")), chunk("XMP ", []byte("<x:xmpmeta/>"))),
+			want: riffWebP(chunk("VP8X", append([]byte{0x10}, vp8x[1:]...)), bitstream),
+		},
+		{
+			name: "ICC profile kept",
+			data: riffWebP(chunk("VP8X", vp8x), chunk("ICCP", []byte("icc")), chunk("EXIF", []byte("e")), bitstream),
+			want: riffWebP(chunk("VP8X", append([]byte{0x10}, vp8x[1:]...)), chunk("ICCP", []byte("icc")), bitstream),
+		},
+		{
+			name: "simple format unchanged",
+			data: riffWebP(bitstream),
+			want: riffWebP(bitstream),
+		},
+		{
+			name: "missing padding byte at end",
+			data: riffWebP(bitstream, chunk("EXIF", []byte("abc")))[:len(riffWebP(bitstream, chunk("EXIF", []byte("abc"))))-1],
+			want: riffWebP(bitstream),
+		},
+		{name: "truncated chunk", data: riffWebP(bitstream)[:len(riffWebP(bitstream))-2], wantErr: true},
+		{name: "not riff", data: []byte("RIFX\x00\x00\x00\x00WEBP"), wantErr: true},
+		{name: "too short", data: []byte("RIFF"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stripWebPMetadata(tt.data, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !bytes.Equal(got.Data, tt.want) {
+				t.Errorf("data = %q\nwant   %q", got.Data, tt.want)
+			}
+			if got.ContentType != "image/webp" || got.Width != 4 || got.Height != 2 {
+				t.Errorf("encoded = %+v", got)
+			}
+		})
+	}
+}
+
+func FuzzJpegOrientation(f *testing.F) {
+	jpg := sampleJPEG(f)
+	f.Add(jpg)
+	f.Add(withSegments(jpg, exifSegment(exifTIFF(binary.BigEndian, [2]uint16{0x0112, 6}))))
+	f.Add(withSegments(jpg, exifSegment(exifTIFF(binary.LittleEndian, [2]uint16{0x010F, 1}, [2]uint16{0x0112, 3}))))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if o := jpegOrientation(data); o < 1 || o > 8 {
+			t.Errorf("jpegOrientation() = %d", o)
+		}
+	})
+}
+
+func FuzzStripWebPMetadata(f *testing.F) {
+	f.Add(riffWebP(chunk("VP8X", make([]byte, 10)), chunk("VP8L", []byte{0x2f, 1}), chunk("EXIF", []byte("e"))))
+	f.Add(riffWebP(chunk("VP8 ", []byte{1, 2, 3})))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		got, err := stripWebPMetadata(data, image.Config{})
+		if err != nil {
+			return
+		}
+		if len(got.Data) > len(data) {
+			t.Errorf("output %d byte lebih besar dari input %d byte", len(got.Data), len(data))
+		}
+		if size := binary.LittleEndian.Uint32(got.Data[4:]); int(size) != len(got.Data)-8 {
+			t.Errorf("ukuran RIFF %d, want %d", size, len(got.Data)-8)
+		}
+	})
+}
@@ -3,9 +3,14 @@ package main
 import (
 	"log"
 	"net/http"
+	_ "time/tzdata" // database zona waktu ikut di-embed, image alpine tidak punya tzdata
 
+	"cash-flow-go/cache"
 	db "cash-flow-go/database"
 	"cash-flow-go/handlers"
+	"cash-flow-go/notifications"
+	"cash-flow-go/storage"
+	"cash-flow-go/webhooks"
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -17,6 +22,15 @@ import (
 
 func main() {
 	db.Init() // connect DB + migrate
+	storage.Init()
+	cache.Init()
+	if err := db.EnsureDailySummaries(db.DB, handlers.Timezone()); err != nil {
+		log.Println("Gagal membangun rollup dashboard: " + err.Error())
+	}
+	handlers.StartAnomalyScanner()
+	handlers.StartBillReminders()
+	webhooks.Start()
+	notifications.Start()
 
 	r := mux.NewRouter()
 
@@ -25,16 +39,105 @@ func main() {
 	r.HandleFunc("/api/transactions/{id}", handlers.DeleteTransaction).Methods("DELETE")
 	r.HandleFunc("/api/transactions/top5", handlers.GetTop5Transactions).Methods("GET")
 
+	r.HandleFunc("/api/transactions/{id}/submit", handlers.SubmitTransaction).Methods("POST")
+	r.HandleFunc("/api/transactions/{id}/approve", handlers.ApproveTransaction).Methods("POST")
+	r.HandleFunc("/api/transactions/{id}/reject", handlers.RejectTransaction).Methods("POST")
+	r.HandleFunc("/api/transactions/{id}/history", handlers.GetApprovalHistory).Methods("GET")
+	r.HandleFunc("/api/transactions/{id}/attachments", handlers.UploadAttachments).Methods("POST")
+	r.HandleFunc("/api/transactions/{id}/attachments", handlers.GetAttachments).Methods("GET")
+	r.HandleFunc("/api/attachments/{id}/download", handlers.DownloadAttachment).Methods("GET")
+	r.HandleFunc("/api/attachments/{id}", handlers.DeleteAttachment).Methods("DELETE")
+
+	r.HandleFunc("/api/approvals/pending", handlers.GetPendingApprovals).Methods("GET")
+	r.HandleFunc("/api/approvals/mine", handlers.GetMyApprovals).Methods("GET")
+
 	r.HandleFunc("/api/dashboard", handlers.GetDashboard).Methods("GET")
 	r.HandleFunc("/api/dashboard/bar", handlers.GetBarChart).Methods("GET")
 	r.HandleFunc("/api/dashboard/donut", handlers.GetDonutChart).Methods("GET")
+	r.HandleFunc("/api/dashboard/breakdown", handlers.GetBreakdown).Methods("GET")
 	r.HandleFunc("/api/dashboard/monthly-bar", handlers.GetMonthlyBarChart).Methods("GET")
+	r.HandleFunc("/api/dashboard/compare", handlers.GetComparison).Methods("GET")
+	r.HandleFunc("/api/dashboard/yoy", handlers.GetYearOverYear).Methods("GET")
+	r.HandleFunc("/api/events", handlers.StreamEvents).Methods("GET")
+
+	r.HandleFunc("/api/recurring", handlers.CreateRecurringTransaction).Methods("POST")
+	r.HandleFunc("/api/recurring", handlers.GetRecurringTransactions).Methods("GET")
+	r.HandleFunc("/api/recurring/{id}", handlers.DeleteRecurringTransaction).Methods("DELETE")
+	r.HandleFunc("/api/forecast", handlers.GetForecast).Methods("GET")
+
+	r.HandleFunc("/api/net-worth", handlers.GetNetWorth).Methods("GET")
+	r.HandleFunc("/api/net-worth/items", handlers.CreateNetWorthItem).Methods("POST")
+	r.HandleFunc("/api/net-worth/items", handlers.GetNetWorthItems).Methods("GET")
+	r.HandleFunc("/api/net-worth/items/{id}", handlers.UpdateNetWorthItem).Methods("PUT")
+	r.HandleFunc("/api/net-worth/items/{id}", handlers.DeleteNetWorthItem).Methods("DELETE")
+	r.HandleFunc("/api/net-worth/items/{id}/valuations", handlers.AddValuation).Methods("POST")
+	r.HandleFunc("/api/net-worth/valuations/{id}", handlers.DeleteValuation).Methods("DELETE")
+
+	r.HandleFunc("/api/debts", handlers.CreateDebt).Methods("POST")
+	r.HandleFunc("/api/debts", handlers.GetDebts).Methods("GET")
+	r.HandleFunc("/api/debts/overdue", handlers.GetOverdueDebts).Methods("GET")
+	r.HandleFunc("/api/debts/balances", handlers.GetDebtBalances).Methods("GET")
+	r.HandleFunc("/api/debts/repayments/{id}", handlers.DeleteRepayment).Methods("DELETE")
+	r.HandleFunc("/api/debts/{id}", handlers.GetDebt).Methods("GET")
+	r.HandleFunc("/api/debts/{id}", handlers.UpdateDebt).Methods("PUT")
+	r.HandleFunc("/api/debts/{id}", handlers.DeleteDebt).Methods("DELETE")
+	r.HandleFunc("/api/debts/{id}/repayments", handlers.AddRepayment).Methods("POST")
+
+	r.HandleFunc("/api/installments", handlers.CreateInstallmentPlan).Methods("POST")
+	r.HandleFunc("/api/installments", handlers.GetInstallmentPlans).Methods("GET")
+	r.HandleFunc("/api/installments/{id}", handlers.GetInstallmentPlan).Methods("GET")
+	r.HandleFunc("/api/installments/{id}", handlers.DeleteInstallmentPlan).Methods("DELETE")
+	r.HandleFunc("/api/installments/{id}/pay", handlers.PayInstallment).Methods("POST")
+	r.HandleFunc("/api/installments/{id}/cancel", handlers.CancelInstallmentPlan).Methods("POST")
+	r.HandleFunc("/api/installments/{id}/installments/{number}/payment", handlers.UnpayInstallment).Methods("DELETE")
+
+	// read-all, preferences dan test didaftarkan sebelum {id} supaya tidak dianggap ID
+	r.HandleFunc("/api/notifications", handlers.GetNotifications).Methods("GET")
+	r.HandleFunc("/api/notifications/read-all", handlers.ReadAllNotifications).Methods("POST")
+	r.HandleFunc("/api/notifications/preferences", handlers.GetNotificationPreferences).Methods("GET")
+	r.HandleFunc("/api/notifications/preferences", handlers.UpdateNotificationPreferences).Methods("PUT")
+	r.HandleFunc("/api/notifications/test", handlers.TestNotification).Methods("POST")
+	r.HandleFunc("/api/notifications/{id}/read", handlers.ReadNotification).Methods("POST")
+	r.HandleFunc("/api/notifications/{id}", handlers.DeleteNotification).Methods("DELETE")
+
+	// upcoming didaftarkan sebelum {id} supaya tidak dianggap ID
+	r.HandleFunc("/api/bills/upcoming", handlers.GetUpcomingBills).Methods("GET")
+	r.HandleFunc("/api/bills", handlers.CreateBill).Methods("POST")
+	r.HandleFunc("/api/bills", handlers.GetBills).Methods("GET")
+	r.HandleFunc("/api/bills/{id}", handlers.UpdateBill).Methods("PUT")
+	r.HandleFunc("/api/bills/{id}", handlers.DeleteBill).Methods("DELETE")
+	r.HandleFunc("/api/bills/{id}/pay", handlers.PayBill).Methods("POST")
+	r.HandleFunc("/api/bills/{id}/payments/{period}", handlers.UnpayBill).Methods("DELETE")
+
+	r.HandleFunc("/api/anomalies", handlers.GetAnomalies).Methods("GET")
+	r.HandleFunc("/api/anomalies/scan", handlers.ScanAnomalies).Methods("POST")
+	r.HandleFunc("/api/anomalies/{id}/dismiss", handlers.DismissAnomaly).Methods("POST")
+
+	// dead-letters dan deliveries didaftarkan sebelum {id} supaya tidak dianggap ID
+	r.HandleFunc("/api/webhooks/dead-letters", handlers.GetDeadLetters).Methods("GET")
+	r.HandleFunc("/api/webhooks/deliveries/{id}", handlers.GetWebhookDelivery).Methods("GET")
+	r.HandleFunc("/api/webhooks/deliveries/{id}/redeliver", handlers.RedeliverWebhook).Methods("POST")
+	r.HandleFunc("/api/webhooks", handlers.CreateWebhook).Methods("POST")
+	r.HandleFunc("/api/webhooks", handlers.ListWebhooks).Methods("GET")
+	r.HandleFunc("/api/webhooks/{id}", handlers.GetWebhook).Methods("GET")
+	r.HandleFunc("/api/webhooks/{id}", handlers.UpdateWebhook).Methods("PUT")
+	r.HandleFunc("/api/webhooks/{id}", handlers.DeleteWebhook).Methods("DELETE")
+	r.HandleFunc("/api/webhooks/{id}/deliveries", handlers.GetWebhookDeliveries).Methods("GET")
 
 	r.HandleFunc("/api/campaigns", handlers.CreateCampaign).Methods("POST")
+	r.HandleFunc("/api/campaigns", handlers.ListCampaigns).Methods("GET")
 	r.HandleFunc("/api/campaigns/active", handlers.GetActiveCampaign).Methods("GET")
+	r.HandleFunc("/api/campaigns/{id}", handlers.GetCampaign).Methods("GET")
+	r.HandleFunc("/api/campaigns/{id}", handlers.UpdateCampaign).Methods("PUT")
+	r.HandleFunc("/api/campaigns/{id}", handlers.DeleteCampaign).Methods("DELETE")
+	r.HandleFunc("/api/campaigns/{id}/activate", handlers.ActivateCampaign).Methods("POST")
+	r.HandleFunc("/api/campaigns/{id}/deactivate", handlers.DeactivateCampaign).Methods("POST")
+	r.HandleFunc("/api/campaigns/{id}/impression", handlers.TrackCampaignImpression).Methods("GET", "POST")
+	r.HandleFunc("/api/campaigns/{id}/click", handlers.TrackCampaignClick).Methods("GET")
+	r.HandleFunc("/api/campaigns/{id}/stats", handlers.GetCampaignStats).Methods("GET")
 
-	// Serve static files (for image access)
-	r.PathPrefix("/uploads/").Handler(http.StripPrefix("/uploads/", http.FileServer(http.Dir("./uploads"))))
+	// Signed URL untuk file di storage lokal (S3 memakai presigned URL langsung ke bucket)
+	r.PathPrefix("/files/").Handler(storage.FileHandler())
 
 	// Swagger endpoint
 	// Swagger endpoint (pastikan pakai handler, bukan "value")
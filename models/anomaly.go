@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Anomaly adalah pengeluaran tidak biasa yang ditemukan detektor.
+// DedupKey mencegah anomali yang sama dicatat dua kali (misal oleh create dan job periodik).
+type Anomaly struct {
+	ID            uint    `json:"id" example:"1" gorm:"primaryKey"`
+	Kind          string  `json:"kind" example:"category_spike" gorm:"index"`
+	DedupKey      string  `json:"-" gorm:"uniqueIndex"`
+	TransactionID *uint   `json:"transaction_id,omitempty" example:"42" gorm:"index"`
+	Category      string  `json:"category,omitempty" example:"makanan"`
+	Payee         string  `json:"payee,omitempty" example:"Netflix"`
+	Period        string  `json:"period,omitempty" example:"2025-09"`
+	Amount        float64 `json:"amount" example:"3600000"`
+	Expected      float64 `json:"expected" example:"1200000"`
+	Score         float64 `json:"score" example:"3"`
+	Explanation   string  `json:"explanation" example:"Pengeluaran makanan bulan ini sudah Rp3.600.000, 3.0x rata-rata bulanan (median Rp1.200.000 dari 6 bulan)"`
+	Dismissed     bool    `json:"dismissed" gorm:"index"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AnomalyScan menyimpan waktu scan anomali terakhir (satu baris, ID 1) supaya scan
+// berikutnya tetap mulai dari titik yang benar setelah restart.
+type AnomalyScan struct {
+	ID         uint      `gorm:"primaryKey"`
+	LastScanAt time.Time `gorm:"not null"`
+}
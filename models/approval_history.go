@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ApprovalHistory mencatat setiap perubahan status approval sebuah transaksi
+type ApprovalHistory struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	TransactionID uint      `json:"transaction_id" gorm:"index"`
+	FromStatus    string    `json:"from_status" example:"submitted"`
+	ToStatus      string    `json:"to_status" example:"approved"`
+	Actor         string    `json:"actor" example:"manager-1"`
+	Comment       string    `json:"comment" example:"Oke, sesuai budget"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Attachment adalah file bukti (foto struk / PDF) milik sebuah transaksi
+type Attachment struct {
+	ID            uint      `json:"id" example:"1" gorm:"primaryKey"`
+	TransactionID uint      `json:"transaction_id" example:"1" gorm:"index"`
+	FileName      string    `json:"file_name" example:"struk-gacoan.jpg"`
+	ContentType   string    `json:"content_type" example:"image/jpeg"`
+	Size          int64     `json:"size" example:"204800"`
+	StorageKey    string    `json:"-"`
+	UploadedBy    string    `json:"uploaded_by" example:"budi"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-08-07T12:00:00Z"`
+
+	DownloadURL string `json:"download_url" gorm:"-" example:"/api/attachments/1/download"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Jenis nominal tagihan
+const (
+	BillFixed     = "fixed"     // nominal sama tiap bulan (internet, BPJS)
+	BillEstimated = "estimated" // berubah-ubah (listrik, air, kartu kredit)
+)
+
+// Jenis pengingat tagihan, sekaligus tipe event yang dikirim
+const (
+	ReminderUpcoming = "upcoming" // ReminderDays sebelum jatuh tempo
+	ReminderDue      = "due"      // hari jatuh tempo
+	ReminderOverdue  = "overdue"  // sehari setelah jatuh tempo
+)
+
+// Bill adalah tagihan bulanan dengan jatuh tempo tetap (listrik, air, internet, BPJS,
+// kartu kredit). Pembayaran tiap bulan dicatat di BillPayment, dicocokkan otomatis dari
+// transaksi pengeluaran dengan payee yang sama atau ditandai manual.
+type Bill struct {
+	ID         uint    `json:"id" example:"1" gorm:"primaryKey"`
+	Name       string  `json:"name" example:"Listrik PLN"`
+	Category   string  `json:"category" example:"listrik"`
+	Payee      string  `json:"payee" example:"PLN" gorm:"index"`
+	Account    string  `json:"account,omitempty" example:"bca"`
+	Amount     float64 `json:"amount" example:"450000"`
+	AmountType string  `json:"amount_type" example:"estimated"`
+	// DueDay tanggal jatuh tempo tiap bulan; 29-31 jatuh di akhir bulan pada bulan yang lebih pendek
+	DueDay       int  `json:"due_day" example:"20"`
+	ReminderDays int  `json:"reminder_days" example:"3"`
+	IsActive     bool `json:"is_active" example:"true" gorm:"index"`
+
+	Workspace string    `json:"workspace,omitempty" example:"keluarga-budi"`
+	CreatedBy string    `json:"created_by" example:"budi"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DueDate mengembalikan tanggal jatuh tempo tagihan pada bulan yang memuat month (00:00 UTC)
+func (b Bill) DueDate(month time.Time) time.Time {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(max(b.DueDay, 1), last)-1)
+}
+
+// Accepts mengecek apakah nominal transaksi masuk akal sebagai pembayaran tagihan ini.
+// Tagihan fixed boleh selisih 1% (minimal Rp1.000), estimated antara setengah sampai dua kali perkiraan.
+func (b Bill) Accepts(amount, estimate float64) bool {
+	if b.AmountType == BillFixed {
+		return math.Abs(amount-b.Amount) <= math.Max(1000, b.Amount/100)
+	}
+	if estimate <= 0 {
+		return amount > 0
+	}
+	return amount >= estimate/2 && amount <= estimate*2
+}
+
+// BillPayment menandai tagihan satu periode (YYYY-MM) sudah dibayar
+type BillPayment struct {
+	ID            uint      `json:"id" example:"1" gorm:"primaryKey"`
+	BillID        uint      `json:"bill_id" example:"1" gorm:"uniqueIndex:idx_bill_payment_period"`
+	Period        string    `json:"period" example:"2025-08" gorm:"uniqueIndex:idx_bill_payment_period"`
+	Amount        float64   `json:"amount" example:"432000"`
+	PaidAt        time.Time `json:"paid_at"`
+	TransactionID *uint     `json:"transaction_id,omitempty" example:"91" gorm:"uniqueIndex"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BillReminder mencatat pengingat yang sudah dikirim supaya tidak terkirim dua kali,
+// termasuk jika scanner jalan di beberapa replica
+type BillReminder struct {
+	ID     uint      `gorm:"primaryKey"`
+	BillID uint      `gorm:"uniqueIndex:idx_bill_reminder"`
+	Period string    `gorm:"uniqueIndex:idx_bill_reminder"`
+	Kind   string    `gorm:"uniqueIndex:idx_bill_reminder"`
+	SentAt time.Time `gorm:"autoCreateTime"`
+}
+
+// Status tagihan satu periode
+const (
+	BillUnpaid  = "unpaid"
+	BillOverdue = "overdue"
+	BillPaid    = "paid"
+)
+
+// UpcomingBill adalah tagihan satu periode untuk dashboard
+type UpcomingBill struct {
+	BillID    uint         `json:"bill_id" example:"1"`
+	Name      string       `json:"name" example:"Listrik PLN"`
+	Category  string       `json:"category" example:"listrik"`
+	Period    string       `json:"period" example:"2025-09"`
+	DueDate   string       `json:"due_date" example:"2025-09-20"`
+	DaysUntil int          `json:"days_until" example:"3"`
+	Amount    float64      `json:"amount" example:"450000"`
+	Estimated bool         `json:"estimated" example:"true"`
+	Status    string       `json:"status" example:"unpaid"`
+	Payment   *BillPayment `json:"payment,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// BreakdownItem adalah satu irisan breakdown dashboard
+type BreakdownItem struct {
+	Key        string  `json:"key" example:"makanan"`
+	Total      float64 `json:"total" example:"250000"`
+	Count      int64   `json:"count" example:"12"`
+	Percentage float64 `json:"percentage" example:"35.5"`
+}
+
+// BreakdownResponse adalah hasil /api/dashboard/breakdown.
+// Irisan di luar top-N digabung ke item dengan key "others".
+type BreakdownResponse struct {
+	By    string          `json:"by" example:"category"`
+	Type  string          `json:"type" example:"pengeluaran"`
+	Total float64         `json:"total" example:"704000"`
+	Items []BreakdownItem `json:"items"`
+}
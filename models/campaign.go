@@ -0,0 +1,184 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Status campaign, dihitung dari IsActive dan rentang waktu
+const (
+	CampaignScheduled = "scheduled"
+	CampaignLive      = "live"
+	CampaignExpired   = "expired"
+	CampaignDisabled  = "disabled"
+)
+
+// DefaultCampaignTimezone dipakai jika campaign tidak menyebutkan zona waktu
+const DefaultCampaignTimezone = "Asia/Jakarta"
+
+// RecurrenceDays yang valid (singkatan hari dalam bahasa Inggris)
+var weekdayCodes = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func IsValidWeekdayCode(code string) bool {
+	_, ok := weekdayCodes[code]
+	return ok
+}
+
+// Placement tempat campaign ditampilkan di aplikasi
+const (
+	PlacementDashboardTop    = "dashboard-top"
+	PlacementTransactionList = "transaction-list"
+	PlacementSplash          = "splash"
+)
+
+var Placements = []string{PlacementDashboardTop, PlacementTransactionList, PlacementSplash}
+
+func IsValidPlacement(p string) bool {
+	for _, v := range Placements {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Campaign adalah banner promo yang tampil selama rentang StartAt - EndAt
+type Campaign struct {
+	ID       uint   `json:"id" example:"1" gorm:"primaryKey"`
+	ImageURL string `json:"image_url" gorm:"-" example:"http://localhost:8889/files/campaigns/1700000000_banner.jpg?expires=1700003600&signature=abc"`
+	ImageKey string `json:"-"`
+	IsActive bool   `json:"is_active" gorm:"index" example:"true"`
+	Status   string `json:"status" gorm:"-" example:"live"`
+	ClickURL string `json:"click_url" example:"https://example.com/promo"`
+
+	// Placement dan rotasi: di antara campaign yang overlap, prioritas tertinggi menang,
+	// lalu dipilih acak berbobot berdasarkan Weight.
+	Placement string `json:"placement" gorm:"index;default:dashboard-top" example:"dashboard-top"`
+	Priority  int    `json:"priority" example:"10"`
+	Weight    int    `json:"weight" gorm:"default:1" example:"1"`
+
+	// Targeting opsional, kosong berarti tampil untuk semua
+	TargetRoles      pq.StringArray `json:"target_roles" gorm:"type:text[]" swaggertype:"array,string" example:"[\"manager\"]"`
+	TargetWorkspaces pq.StringArray `json:"target_workspaces" gorm:"type:text[]" swaggertype:"array,string" example:"[\"keluarga-budi\"]"`
+
+	// Gambar sudah diproses: EXIF dibuang dan varian responsif dibuat saat upload
+	ImageWidth   int               `json:"image_width" example:"1920"`
+	ImageHeight  int               `json:"image_height" example:"640"`
+	ThumbnailKey string            `json:"-"`
+	MobileKey    string            `json:"-"`
+	DesktopKey   string            `json:"-"`
+	Variants     map[string]string `json:"variants" gorm:"-" example:"thumbnail:http://localhost:8889/files/campaigns/1700000000_banner_thumbnail.jpg"`
+
+	// StartAt / EndAt disimpan dalam UTC dan ditampilkan dalam Timezone campaign
+	StartAt  time.Time `json:"start_at" example:"2025-08-07T08:00:00+07:00"`
+	EndAt    time.Time `json:"end_at" example:"2025-08-14T08:00:00+07:00"`
+	Timezone string    `json:"timezone" gorm:"default:Asia/Jakarta" example:"Asia/Jakarta"`
+
+	// Jadwal berulang opsional di dalam StartAt - EndAt, misal tiap Jumat 17:00-21:00.
+	// Jam dihitung dalam Timezone campaign; End <= Start berarti melewati tengah malam.
+	RecurrenceDays  pq.StringArray `json:"recurrence_days" gorm:"type:text[]" swaggertype:"array,string" example:"[\"fri\"]"`
+	RecurrenceStart string         `json:"recurrence_start" example:"17:00"`
+	RecurrenceEnd   string         `json:"recurrence_end" example:"21:00"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Location mengembalikan zona waktu campaign (default Asia/Jakarta)
+func (c Campaign) Location() *time.Location {
+	name := c.Timezone
+	if name == "" {
+		name = DefaultCampaignTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// InRecurringWindow mengecek apakah now masuk jadwal berulang campaign.
+// Campaign tanpa jadwal berulang selalu true.
+func (c Campaign) InRecurringWindow(now time.Time) bool {
+	if len(c.RecurrenceDays) == 0 {
+		return true
+	}
+	start, okStart := clockMinutes(c.RecurrenceStart)
+	end, okEnd := clockMinutes(c.RecurrenceEnd)
+	if !okStart || !okEnd {
+		return false
+	}
+
+	local := now.In(c.Location())
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return c.recursOn(local.Weekday()) && minute >= start && minute < end
+	}
+	// Window melewati tengah malam: bagian malam milik hari ini, bagian dini hari milik kemarin
+	return (c.recursOn(local.Weekday()) && minute >= start) ||
+		(c.recursOn(local.AddDate(0, 0, -1).Weekday()) && minute < end)
+}
+
+func (c Campaign) recursOn(day time.Weekday) bool {
+	for _, code := range c.RecurrenceDays {
+		if d, ok := weekdayCodes[code]; ok && d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock memvalidasi jam format HH:MM
+func ParseClock(v string) bool {
+	_, ok := clockMinutes(v)
+	return ok
+}
+
+func clockMinutes(v string) (int, bool) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// VariantKeys memetakan nama varian ke key storage-nya
+func (c Campaign) VariantKeys() map[string]string {
+	keys := map[string]string{}
+	for name, key := range map[string]string{"thumbnail": c.ThumbnailKey, "mobile": c.MobileKey, "desktop": c.DesktopKey} {
+		if key != "" {
+			keys[name] = key
+		}
+	}
+	return keys
+}
+
+// ImageKeys adalah semua file milik campaign (asli + varian), dipakai saat menghapus / mengganti gambar
+func (c Campaign) ImageKeys() []string {
+	keys := []string{c.ImageKey}
+	for _, key := range c.VariantKeys() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// StatusAt menghitung status campaign pada waktu now
+func (c Campaign) StatusAt(now time.Time) string {
+	switch {
+	case !c.IsActive:
+		return CampaignDisabled
+	case now.Before(c.StartAt):
+		return CampaignScheduled
+	case !now.Before(c.EndAt):
+		return CampaignExpired
+	case !c.InRecurringWindow(now):
+		// Masih dalam periode, tapi menunggu slot jadwal berulang berikutnya
+		return CampaignScheduled
+	default:
+		return CampaignLive
+	}
+}
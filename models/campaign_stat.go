@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Jenis event analytics campaign
+const (
+	CampaignEventImpression = "impression"
+	CampaignEventClick      = "click"
+)
+
+// CampaignDailyStat adalah counter agregat impression & click per campaign per hari (zona aplikasi)
+type CampaignDailyStat struct {
+	CampaignID  uint      `json:"campaign_id" gorm:"primaryKey;autoIncrement:false"`
+	Day         time.Time `json:"day" gorm:"primaryKey;type:date"`
+	Impressions int64     `json:"impressions"`
+	Clicks      int64     `json:"clicks"`
+}
+
+// CampaignViewer dipakai untuk dedup: satu user / sesi hanya dihitung sekali per hari per jenis event
+type CampaignViewer struct {
+	CampaignID uint      `gorm:"primaryKey;autoIncrement:false"`
+	Day        time.Time `gorm:"primaryKey;type:date"`
+	Kind       string    `gorm:"primaryKey"`
+	ViewerKey  string    `gorm:"primaryKey"`
+}
+
+// CampaignDayStat adalah satu baris statistik harian di response
+type CampaignDayStat struct {
+	Date        string  `json:"date" example:"2025-08-07"`
+	Impressions int64   `json:"impressions" example:"120"`
+	Clicks      int64   `json:"clicks" example:"6"`
+	CTR         float64 `json:"ctr" example:"5"`
+}
+
+// CampaignStatsResponse adalah ringkasan performa campaign selama StartAt - EndAt
+type CampaignStatsResponse struct {
+	CampaignID  uint              `json:"campaign_id" example:"1"`
+	From        string            `json:"from" example:"2025-08-07"`
+	To          string            `json:"to" example:"2025-08-14"`
+	Impressions int64             `json:"impressions" example:"840"`
+	Clicks      int64             `json:"clicks" example:"42"`
+	CTR         float64           `json:"ctr" example:"5"`
+	Daily       []CampaignDayStat `json:"daily"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestCampaignInRecurringWindow(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("tzdata tidak tersedia")
+	}
+	// 5 September 2025 hari Jumat
+	wib := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 9, day, hour, minute, 0, 0, jakarta)
+	}
+	utc := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 9, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	friday := Campaign{RecurrenceDays: pq.StringArray{"fri"}, RecurrenceStart: "17:00", RecurrenceEnd: "21:00"}
+	overnight := Campaign{RecurrenceDays: pq.StringArray{"fri", "sat"}, RecurrenceStart: "22:00", RecurrenceEnd: "02:00"}
+	saturdayNight := Campaign{RecurrenceDays: pq.StringArray{"sat"}, RecurrenceStart: "22:00", RecurrenceEnd: "02:00"}
+	newYork := Campaign{Timezone: "America/New_York", RecurrenceDays: pq.StringArray{"mon"}, RecurrenceStart: "09:00", RecurrenceEnd: "10:00"}
+
+	tests := []struct {
+		name     string
+		campaign Campaign
+		now      time.Time
+		want     bool
+	}{
+		{"no recurrence", Campaign{}, wib(3, 3, 0), true},
+		{"start is inclusive", friday, wib(5, 17, 0), true},
+		{"inside window", friday, wib(5, 20, 59), true},
+		{"end is exclusive", friday, wib(5, 21, 0), false},
+		{"before start", friday, wib(5, 16, 59), false},
+		{"other weekday", friday, wib(4, 18, 0), false},
+		{"UTC converted to campaign zone", friday, utc(5, 11, 0), true},
+		{"UTC friday is saturday in Jakarta", friday, utc(5, 20, 0), false},
+		{"UTC thursday is friday in Jakarta", Campaign{RecurrenceDays: pq.StringArray{"fri"}, RecurrenceStart: "05:00", RecurrenceEnd: "09:00"}, utc(4, 23, 0), true},
+		{"other campaign zone", newYork, utc(8, 13, 30), true},
+		{"other campaign zone, Jakarta hour", newYork, wib(8, 9, 30), false},
+		{"overnight evening part", overnight, wib(5, 23, 0), true},
+		{"overnight early part belongs to previous day", overnight, wib(6, 1, 30), true},
+		{"overnight end is exclusive", overnight, wib(6, 2, 0), false},
+		{"overnight from saturday into sunday", overnight, wib(7, 1, 0), true},
+		{"overnight previous day not scheduled", overnight, wib(5, 1, 0), false},
+		{"overnight after last day", overnight, wib(8, 1, 0), false},
+		{"overnight evening of unscheduled day", overnight, wib(4, 23, 0), false},
+		{"week wraps from saturday to sunday", saturdayNight, wib(7, 0, 30), true},
+		{"saturday early part belongs to friday", saturdayNight, wib(6, 0, 30), false},
+		{"unknown day code ignored", Campaign{RecurrenceDays: pq.StringArray{"jumat"}, RecurrenceStart: "00:00", RecurrenceEnd: "23:59"}, wib(5, 12, 0), false},
+		{"invalid clock", Campaign{RecurrenceDays: pq.StringArray{"fri"}, RecurrenceStart: "5pm", RecurrenceEnd: "21:00"}, wib(5, 18, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.campaign.InRecurringWindow(tt.now); got != tt.want {
+				t.Errorf("InRecurringWindow(%s) = %v, want %v", tt.now.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCampaignStatusAt(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 9, 30, 1, 0, 0, 0, time.UTC)
+	base := Campaign{IsActive: true, StartAt: start, EndAt: end}
+	weekly := base
+	weekly.RecurrenceDays = pq.StringArray{"fri"}
+	weekly.RecurrenceStart, weekly.RecurrenceEnd = "17:00", "21:00"
+	disabled := base
+	disabled.IsActive = false
+
+	tests := []struct {
+		name     string
+		campaign Campaign
+		now      time.Time
+		want     string
+	}{
+		{"disabled", disabled, start.Add(time.Hour), CampaignDisabled},
+		{"before start", base, start.Add(-time.Second), CampaignScheduled},
+		{"at start", base, start, CampaignLive},
+		{"at end", base, end, CampaignExpired},
+		{"waiting for recurring slot", weekly, time.Date(2025, 9, 5, 5, 0, 0, 0, time.UTC), CampaignScheduled},
+		{"in recurring slot", weekly, time.Date(2025, 9, 5, 11, 0, 0, 0, time.UTC), CampaignLive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.campaign.StatusAt(tt.now); got != tt.want {
+				t.Errorf("StatusAt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
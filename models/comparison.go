@@ -0,0 +1,63 @@
+package models
+
+import "math"
+
+// PeriodRange adalah rentang tanggal inklusif (zona aplikasi)
+type PeriodRange struct {
+	From string `json:"from" example:"2025-09-01"`
+	To   string `json:"to" example:"2025-09-30"`
+}
+
+// Delta membandingkan nilai periode sekarang dengan periode pembanding.
+// ChangePercent null jika periode pembanding bernilai 0; tandanya selalu mengikuti Change,
+// juga saat periode pembanding negatif (misal net yang defisit).
+type Delta struct {
+	Current       float64  `json:"current" example:"3500000"`
+	Previous      float64  `json:"previous" example:"3000000"`
+	Change        float64  `json:"change" example:"500000"`
+	ChangePercent *float64 `json:"change_percent" example:"16.67"`
+}
+
+// NewDelta menghitung selisih absolut dan persen
+func NewDelta(current, previous float64) Delta {
+	d := Delta{Current: current, Previous: previous, Change: current - previous}
+	if previous != 0 {
+		pct := d.Change / math.Abs(previous) * 100
+		d.ChangePercent = &pct
+	}
+	return d
+}
+
+// CategoryDelta adalah delta satu kategori untuk satu type
+type CategoryDelta struct {
+	Category string `json:"category" example:"makanan"`
+	Type     string `json:"type" example:"pengeluaran"`
+	Delta
+}
+
+// ComparisonResponse adalah hasil /api/dashboard/compare
+type ComparisonResponse struct {
+	Current    PeriodRange     `json:"current"`
+	Previous   PeriodRange     `json:"previous"`
+	Income     Delta           `json:"income"`
+	Expense    Delta           `json:"expense"`
+	Net        Delta           `json:"net"`
+	Categories []CategoryDelta `json:"categories"`
+	TopMovers  []CategoryDelta `json:"top_movers"`
+}
+
+// YoYYear adalah angka satu bulan kalender di satu tahun
+type YoYYear struct {
+	Year                 int      `json:"year" example:"2025"`
+	Income               float64  `json:"income" example:"10000000"`
+	Expense              float64  `json:"expense" example:"7500000"`
+	Net                  float64  `json:"net" example:"2500000"`
+	IncomeChangePercent  *float64 `json:"income_change_percent" example:"5.2"`
+	ExpenseChangePercent *float64 `json:"expense_change_percent" example:"-3.1"`
+}
+
+// YoYResponse adalah hasil /api/dashboard/yoy
+type YoYResponse struct {
+	Month string    `json:"month" example:"September"`
+	Years []YoYYear `json:"years"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "testing"
+
+func TestNewDelta(t *testing.T) {
+	pct := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name              string
+		current, previous float64
+		change            float64
+		percent           *float64
+	}{
+		{"increase", 4000000, 3200000, 800000, pct(25)},
+		{"decrease", 1500000, 3000000, -1500000, pct(-50)},
+		{"unchanged", 1000, 1000, 0, pct(0)},
+		{"deficit to surplus", 1000000, -1000000, 2000000, pct(200)},
+		{"smaller deficit", -500000, -1000000, 500000, pct(50)},
+		{"bigger deficit", -1500000, -1000000, -500000, pct(-50)},
+		{"surplus to deficit", -1000000, 1000000, -2000000, pct(-200)},
+		{"previous zero", 500000, 0, 500000, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDelta(tt.current, tt.previous)
+			if d.Current != tt.current || d.Previous != tt.previous || d.Change != tt.change {
+				t.Errorf("NewDelta() = %+v, want change %v", d, tt.change)
+			}
+			switch {
+			case (d.ChangePercent == nil) != (tt.percent == nil):
+				t.Errorf("ChangePercent = %v, want %v", d.ChangePercent, tt.percent)
+			case d.ChangePercent != nil && *d.ChangePercent != *tt.percent:
+				t.Errorf("ChangePercent = %v, want %v", *d.ChangePercent, *tt.percent)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// DailySummary adalah rollup transaksi approved per hari (zona aplikasi), type, kategori utama
+// dan account. Dipakai dashboard untuk total dan saldo tanpa memindai tabel transactions.
+type DailySummary struct {
+	Day      time.Time `json:"day" gorm:"type:date;primaryKey"`
+	Type     string    `json:"type" gorm:"primaryKey"`
+	Category string    `json:"category" gorm:"primaryKey"`
+	Account  string    `json:"account" gorm:"primaryKey"`
+	Total    float64   `json:"total"`
+	Count    int64     `json:"count"`
+}
+
+// DailyCategorySummary sama seperti DailySummary tapi dipecah per elemen categories
+// (transaksi tanpa categories memakai kategori utamanya). Untuk chart per kategori;
+// transaksi multi-kategori terhitung di setiap kategorinya.
+type DailyCategorySummary struct {
+	Day      time.Time `json:"day" gorm:"type:date;primaryKey"`
+	Type     string    `json:"type" gorm:"primaryKey"`
+	Category string    `json:"category" gorm:"primaryKey"`
+	Account  string    `json:"account" gorm:"primaryKey"`
+	Total    float64   `json:"total"`
+	Count    int64     `json:"count"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Arah hutang piutang
+const (
+	DebtPayable    = "hutang"  // kita meminjam, harus membayar
+	DebtReceivable = "piutang" // kita meminjamkan, akan dibayar
+)
+
+// Status hutang piutang
+const (
+	DebtOpen       = "open"
+	DebtPaid       = "paid"        // lunas
+	DebtWrittenOff = "written_off" // diikhlaskan / tidak ditagih lagi
+)
+
+// Debt adalah hutang (pinjam ke keluarga) atau piutang (meminjamkan ke teman).
+// TransactionID menunjuk transaksi pencairan awal jika dicatat; transaksi yang terhubung
+// dengan hutang piutang diberi debt_id supaya bisa dibedakan dari pemasukan / pengeluaran biasa.
+type Debt struct {
+	ID            uint       `json:"id" example:"1" gorm:"primaryKey"`
+	Direction     string     `json:"direction" example:"piutang" gorm:"index"`
+	Counterparty  string     `json:"counterparty" example:"Andi" gorm:"index"`
+	Principal     float64    `json:"principal" example:"2000000"`
+	Repaid        float64    `json:"repaid" example:"500000"`
+	Description   string     `json:"description,omitempty" example:"Pinjam buat DP motor"`
+	StartDate     time.Time  `json:"start_date" gorm:"type:date" example:"2025-08-01T00:00:00Z"`
+	DueDate       *time.Time `json:"due_date,omitempty" gorm:"type:date;index" example:"2025-10-01T00:00:00Z"`
+	Status        string     `json:"status" example:"open" gorm:"index"`
+	TransactionID *uint      `json:"transaction_id,omitempty" example:"42"`
+
+	Repayments []DebtRepayment `json:"repayments,omitempty" gorm:"foreignKey:DebtID"`
+
+	CreatedBy string    `json:"created_by" example:"budi"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Outstanding adalah sisa yang belum dibayar
+func (d Debt) Outstanding() float64 {
+	return max(d.Principal-d.Repaid, 0)
+}
+
+// IsOverdue true jika masih open dan sudah lewat jatuh tempo per tanggal today
+func (d Debt) IsOverdue(today time.Time) bool {
+	return d.Status == DebtOpen && d.DueDate != nil && d.DueDate.Before(today)
+}
+
+// DebtRepayment adalah satu pembayaran (cicilan) hutang piutang, opsional terhubung
+// ke transaksi pemasukan (piutang dibayar) atau pengeluaran (hutang dibayar).
+type DebtRepayment struct {
+	ID            uint      `json:"id" example:"1" gorm:"primaryKey"`
+	DebtID        uint      `json:"debt_id" example:"1" gorm:"index"`
+	Amount        float64   `json:"amount" example:"500000"`
+	Date          time.Time `json:"date" gorm:"type:date" example:"2025-08-25T00:00:00Z"`
+	TransactionID *uint     `json:"transaction_id,omitempty" example:"57" gorm:"index"`
+	Note          string    `json:"note,omitempty" example:"Transfer BCA"`
+
+	CreatedBy string    `json:"created_by" example:"budi"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DebtResponse adalah Debt beserta sisa dan status jatuh tempo
+type DebtResponse struct {
+	Debt
+	Outstanding float64 `json:"outstanding" example:"1500000"`
+	Overdue     bool    `json:"overdue" example:"false"`
+	DaysOverdue int     `json:"days_overdue,omitempty" example:"0"`
+}
+
+// CounterpartyBalance adalah sisa hutang piutang yang masih open per orang.
+// Net positif berarti orang itu masih berhutang ke kita.
+type CounterpartyBalance struct {
+	Counterparty string  `json:"counterparty" example:"Andi"`
+	Receivable   float64 `json:"receivable" example:"1500000"`
+	Payable      float64 `json:"payable" example:"0"`
+	Net          float64 `json:"net" example:"1500000"`
+	OpenCount    int     `json:"open_count" example:"1"`
+	OverdueCount int     `json:"overdue_count" example:"0"`
+}
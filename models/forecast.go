@@ -0,0 +1,38 @@
+package models
+
+// ForecastDay adalah proyeksi saldo satu hari
+type ForecastDay struct {
+	Date     string  `json:"date" example:"2025-09-01"`
+	Known    float64 `json:"known" example:"-1500000"`
+	Variable float64 `json:"variable" example:"85000"`
+	Expected float64 `json:"expected" example:"4200000"`
+	Best     float64 `json:"best" example:"4350000"`
+	Worst    float64 `json:"worst" example:"4050000"`
+}
+
+// ForecastEvent adalah arus kas pasti yang ikut diproyeksikan
+type ForecastEvent struct {
+	Date        string  `json:"date" example:"2025-09-01"`
+	Amount      float64 `json:"amount" example:"-1500000"`
+	Source      string  `json:"source" example:"recurring"`
+	SourceID    uint    `json:"source_id" example:"1"`
+	Description string  `json:"description" example:"Sewa kos"`
+}
+
+// ForecastCategory adalah rata-rata pengeluaran variabel bulanan per kategori
+type ForecastCategory struct {
+	Category string  `json:"category" example:"makanan"`
+	Monthly  float64 `json:"monthly" example:"2100000"`
+	StdDev   float64 `json:"std_dev" example:"300000"`
+}
+
+// ForecastResponse adalah hasil /api/forecast
+type ForecastResponse struct {
+	Days               int                `json:"days" example:"30"`
+	CurrentBalance     float64            `json:"current_balance" example:"5000000"`
+	FirstNegativeDate  *string            `json:"first_negative_date" example:"2025-09-20"`
+	FirstNegativeWorst *string            `json:"first_negative_date_worst" example:"2025-09-14"`
+	Daily              []ForecastDay      `json:"daily"`
+	Events             []ForecastEvent    `json:"events"`
+	Variable           []ForecastCategory `json:"variable"`
+}
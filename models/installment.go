@@ -0,0 +1,131 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Status cicilan
+const (
+	PlanActive    = "active"
+	PlanCompleted = "completed" // semua cicilan lunas
+	PlanCancelled = "cancelled" // dilunasi dipercepat / dibatalkan, sisa cicilan tidak ditagih
+
+	InstallmentPending = "pending"
+	InstallmentPaid    = "paid"
+)
+
+// InstallmentPlan adalah pembelian cicilan (kartu kredit 0%, paylater, kredit toko).
+// Jadwal dibuat sekali saat plan dibuat: pokok dibagi rata per bulan, bunga flat
+// InterestRate persen dari pokok per bulan, MonthlyFee tiap bulan dan AdminFee di
+// cicilan pertama. Payee dan Account dipakai untuk mencocokkan transaksi pembayaran.
+type InstallmentPlan struct {
+	ID           uint      `json:"id" example:"1" gorm:"primaryKey"`
+	Name         string    `json:"name" example:"iPhone 15"`
+	Provider     string    `json:"provider" example:"Kredivo"`
+	Payee        string    `json:"payee" example:"Kredivo" gorm:"index"`
+	Account      string    `json:"account,omitempty" example:"bca"`
+	Category     string    `json:"category,omitempty" example:"elektronik"`
+	Principal    float64   `json:"principal" example:"12000000"`
+	Tenor        int       `json:"tenor" example:"12"`
+	InterestRate float64   `json:"interest_rate" example:"0"`
+	AdminFee     float64   `json:"admin_fee" example:"50000"`
+	MonthlyFee   float64   `json:"monthly_fee" example:"0"`
+	FirstDueDate time.Time `json:"first_due_date" gorm:"type:date" example:"2025-09-05T00:00:00Z"`
+	Status       string    `json:"status" example:"active" gorm:"index"`
+
+	Installments []Installment `json:"installments,omitempty" gorm:"foreignKey:PlanID"`
+
+	CreatedBy string    `json:"created_by" example:"budi"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Installment adalah satu tagihan bulanan dari plan
+type Installment struct {
+	ID            uint       `json:"id" example:"1" gorm:"primaryKey"`
+	PlanID        uint       `json:"plan_id" example:"1" gorm:"index"`
+	Number        int        `json:"number" example:"1"`
+	DueDate       time.Time  `json:"due_date" gorm:"type:date;index" example:"2025-09-05T00:00:00Z"`
+	Principal     float64    `json:"principal" example:"1000000"`
+	Interest      float64    `json:"interest" example:"0"`
+	Fee           float64    `json:"fee" example:"50000"`
+	Amount        float64    `json:"amount" example:"1050000"`
+	Status        string     `json:"status" example:"pending" gorm:"index"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	TransactionID *uint      `json:"transaction_id,omitempty" example:"88" gorm:"index"`
+}
+
+// Schedule membuat jadwal cicilan plan. Nominal dibulatkan ke rupiah; selisih pembulatan
+// pokok masuk ke cicilan terakhir supaya totalnya tepat sama dengan Principal.
+func (p InstallmentPlan) Schedule() []Installment {
+	tenor := max(p.Tenor, 1)
+	monthly := math.Floor(p.Principal / float64(tenor))
+	interest := math.Round(p.Principal * p.InterestRate / 100)
+
+	list := make([]Installment, 0, tenor)
+	for i := 0; i < tenor; i++ {
+		inst := Installment{
+			Number:    i + 1,
+			DueDate:   addMonthsClamped(p.FirstDueDate, i),
+			Principal: monthly,
+			Interest:  interest,
+			Fee:       p.MonthlyFee,
+			Status:    InstallmentPending,
+		}
+		if i == 0 {
+			inst.Fee += p.AdminFee
+		}
+		if i == tenor-1 {
+			inst.Principal = p.Principal - monthly*float64(tenor-1)
+		}
+		inst.Amount = inst.Principal + inst.Interest + inst.Fee
+		list = append(list, inst)
+	}
+	return list
+}
+
+// InstallmentPlanResponse adalah plan beserta ringkasan kewajiban
+type InstallmentPlanResponse struct {
+	InstallmentPlan
+	TotalCost       float64    `json:"total_cost" example:"12050000"`
+	Paid            float64    `json:"paid" example:"2050000"`
+	Remaining       float64    `json:"remaining" example:"10000000"`
+	PaidCount       int        `json:"paid_count" example:"2"`
+	OverdueCount    int        `json:"overdue_count" example:"0"`
+	NextDueDate     *time.Time `json:"next_due_date,omitempty" example:"2025-11-05T00:00:00Z"`
+	NextAmount      float64    `json:"next_amount,omitempty" example:"1000000"`
+	CostRatePerYear float64    `json:"cost_rate_per_year" example:"0.42"`
+}
+
+// Summarize menghitung total biaya, yang sudah dibayar dan sisa kewajiban per tanggal today.
+// Plan yang dibatalkan tidak punya sisa kewajiban.
+func (p InstallmentPlan) Summarize(today time.Time) InstallmentPlanResponse {
+	resp := InstallmentPlanResponse{InstallmentPlan: p}
+	for _, inst := range p.Installments {
+		resp.TotalCost += inst.Amount
+		if inst.Status == InstallmentPaid {
+			resp.Paid += inst.Amount
+			resp.PaidCount++
+			continue
+		}
+		if p.Status == PlanCancelled {
+			continue
+		}
+		resp.Remaining += inst.Amount
+		if inst.DueDate.Before(today) {
+			resp.OverdueCount++
+		}
+		if resp.NextDueDate == nil {
+			due := inst.DueDate
+			resp.NextDueDate, resp.NextAmount = &due, inst.Amount
+		}
+	}
+	// Biaya di atas pokok (bunga + admin) dalam persen per tahun (flat), supaya cicilan
+	// "0%" yang ada biaya adminnya tetap kelihatan biayanya
+	if p.Principal > 0 && p.Tenor > 0 {
+		extra := (resp.TotalCost - p.Principal) / p.Principal * 100
+		resp.CostRatePerYear = math.Round(extra/float64(p.Tenor)*12*100) / 100
+	}
+	return resp
+}
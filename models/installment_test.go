@@ -0,0 +1,171 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestInstallmentPlanSchedule(t *testing.T) {
+	tests := []struct {
+		name   string
+		plan   InstallmentPlan
+		want   []Installment
+		amount float64
+	}{
+		{
+			name: "rounding goes to last installment",
+			plan: InstallmentPlan{Principal: 1000000, Tenor: 3, FirstDueDate: date(2025, 1, 5)},
+			want: []Installment{
+				{Number: 1, DueDate: date(2025, 1, 5), Principal: 333333, Amount: 333333},
+				{Number: 2, DueDate: date(2025, 2, 5), Principal: 333333, Amount: 333333},
+				{Number: 3, DueDate: date(2025, 3, 5), Principal: 333334, Amount: 333334},
+			},
+			amount: 1000000,
+		},
+		{
+			name: "admin fee on first, monthly fee and flat interest on all",
+			plan: InstallmentPlan{
+				Principal: 1000000, Tenor: 3, InterestRate: 1, AdminFee: 50000, MonthlyFee: 5000,
+				FirstDueDate: date(2025, 1, 31),
+			},
+			want: []Installment{
+				{Number: 1, DueDate: date(2025, 1, 31), Principal: 333333, Interest: 10000, Fee: 55000, Amount: 398333},
+				{Number: 2, DueDate: date(2025, 2, 28), Principal: 333333, Interest: 10000, Fee: 5000, Amount: 348333},
+				{Number: 3, DueDate: date(2025, 3, 31), Principal: 333334, Interest: 10000, Fee: 5000, Amount: 348334},
+			},
+			amount: 1095000,
+		},
+		{
+			name: "zero tenor is paid at once",
+			plan: InstallmentPlan{Principal: 750000, AdminFee: 2500, FirstDueDate: date(2025, 6, 10)},
+			want: []Installment{
+				{Number: 1, DueDate: date(2025, 6, 10), Principal: 750000, Fee: 2500, Amount: 752500},
+			},
+			amount: 752500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.plan.Schedule()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Schedule() returned %d installments, want %d", len(got), len(tt.want))
+			}
+
+			var principal, amount float64
+			for i, inst := range got {
+				want := tt.want[i]
+				want.Status = InstallmentPending
+				if inst != want {
+					t.Errorf("installment %d = %+v, want %+v", i+1, inst, want)
+				}
+				principal += inst.Principal
+				amount += inst.Amount
+			}
+			if principal != tt.plan.Principal {
+				t.Errorf("total principal = %.0f, want %.0f", principal, tt.plan.Principal)
+			}
+			if amount != tt.amount {
+				t.Errorf("total amount = %.0f, want %.0f", amount, tt.amount)
+			}
+		})
+	}
+}
+
+func TestInstallmentPlanSummarize(t *testing.T) {
+	scheduled := func(status string, paid int) InstallmentPlan {
+		p := InstallmentPlan{
+			Principal: 1000000, Tenor: 3, InterestRate: 1, AdminFee: 50000, MonthlyFee: 5000,
+			FirstDueDate: date(2025, 1, 31), Status: status,
+		}
+		p.Installments = p.Schedule()
+		for i := 0; i < paid; i++ {
+			p.Installments[i].Status = InstallmentPaid
+		}
+		return p
+	}
+	due := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name  string
+		plan  InstallmentPlan
+		today time.Time
+		want  InstallmentPlanResponse
+	}{
+		{
+			name:  "nothing paid yet",
+			plan:  scheduled(PlanActive, 0),
+			today: date(2025, 1, 20),
+			want: InstallmentPlanResponse{
+				TotalCost: 1095000, Remaining: 1095000,
+				NextDueDate: due(date(2025, 1, 31)), NextAmount: 398333, CostRatePerYear: 38,
+			},
+		},
+		{
+			name:  "first paid, second overdue",
+			plan:  scheduled(PlanActive, 1),
+			today: date(2025, 3, 1),
+			want: InstallmentPlanResponse{
+				TotalCost: 1095000, Paid: 398333, Remaining: 696667, PaidCount: 1, OverdueCount: 1,
+				NextDueDate: due(date(2025, 2, 28)), NextAmount: 348333, CostRatePerYear: 38,
+			},
+		},
+		{
+			name:  "completed",
+			plan:  scheduled(PlanCompleted, 3),
+			today: date(2025, 4, 1),
+			want: InstallmentPlanResponse{
+				TotalCost: 1095000, Paid: 1095000, PaidCount: 3, CostRatePerYear: 38,
+			},
+		},
+		{
+			name:  "cancelled has nothing remaining",
+			plan:  scheduled(PlanCancelled, 1),
+			today: date(2025, 6, 1),
+			want: InstallmentPlanResponse{
+				TotalCost: 1095000, Paid: 398333, PaidCount: 1, CostRatePerYear: 38,
+			},
+		},
+		{
+			name: "zero percent without fees costs nothing",
+			plan: func() InstallmentPlan {
+				p := InstallmentPlan{Principal: 1000000, Tenor: 3, FirstDueDate: date(2025, 1, 5), Status: PlanActive}
+				p.Installments = p.Schedule()
+				return p
+			}(),
+			today: date(2025, 1, 1),
+			want: InstallmentPlanResponse{
+				TotalCost: 1000000, Remaining: 1000000,
+				NextDueDate: due(date(2025, 1, 5)), NextAmount: 333333,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.plan.Summarize(tt.today)
+			if got.TotalCost != tt.want.TotalCost || got.Paid != tt.want.Paid || got.Remaining != tt.want.Remaining {
+				t.Errorf("total/paid/remaining = %.0f/%.0f/%.0f, want %.0f/%.0f/%.0f",
+					got.TotalCost, got.Paid, got.Remaining, tt.want.TotalCost, tt.want.Paid, tt.want.Remaining)
+			}
+			if got.PaidCount != tt.want.PaidCount || got.OverdueCount != tt.want.OverdueCount {
+				t.Errorf("paid/overdue count = %d/%d, want %d/%d",
+					got.PaidCount, got.OverdueCount, tt.want.PaidCount, tt.want.OverdueCount)
+			}
+			switch {
+			case (got.NextDueDate == nil) != (tt.want.NextDueDate == nil):
+				t.Errorf("NextDueDate = %v, want %v", got.NextDueDate, tt.want.NextDueDate)
+			case got.NextDueDate != nil && !got.NextDueDate.Equal(*tt.want.NextDueDate):
+				t.Errorf("NextDueDate = %v, want %v", *got.NextDueDate, *tt.want.NextDueDate)
+			}
+			if got.NextAmount != tt.want.NextAmount {
+				t.Errorf("NextAmount = %.0f, want %.0f", got.NextAmount, tt.want.NextAmount)
+			}
+			if got.CostRatePerYear != tt.want.CostRatePerYear {
+				t.Errorf("CostRatePerYear = %v, want %v", got.CostRatePerYear, tt.want.CostRatePerYear)
+			}
+		})
+	}
+}
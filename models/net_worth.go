@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+// Jenis item net worth
+const (
+	NetWorthAsset     = "asset"
+	NetWorthLiability = "liability"
+)
+
+// Kategori yang dikenal per jenis. Kategori "lainnya" boleh dipakai keduanya.
+var NetWorthCategories = map[string][]string{
+	NetWorthAsset:     {"tabungan", "emas", "properti", "investasi", "kendaraan", "lainnya"},
+	NetWorthLiability: {"kpr", "kredit kendaraan", "paylater", "kartu kredit", "pinjaman", "lainnya"},
+}
+
+func IsValidNetWorthCategory(kind, category string) bool {
+	for _, c := range NetWorthCategories[kind] {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// NetWorthItem adalah aset (tabungan, emas, properti, investasi) atau liabilitas (KPR,
+// kredit kendaraan, paylater) di luar saldo kas transaksi. Nilainya dicatat berkala
+// lewat NetWorthValuation; di antara dua penilaian nilai terakhir yang dipakai.
+type NetWorthItem struct {
+	ID       uint   `json:"id" example:"1" gorm:"primaryKey"`
+	Kind     string `json:"kind" example:"asset" gorm:"index"`
+	Category string `json:"category" example:"emas"`
+	Name     string `json:"name" example:"Emas Antam 10 gram"`
+	Note     string `json:"note,omitempty" example:"Disimpan di brankas"`
+	// ClosedAt diisi saat aset dijual / utang lunas; setelah tanggal ini item tidak dihitung
+	ClosedAt *time.Time `json:"closed_at,omitempty" gorm:"type:date"`
+
+	Valuations []NetWorthValuation `json:"valuations,omitempty" gorm:"foreignKey:ItemID"`
+
+	CreatedBy string    `json:"created_by" example:"budi"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NetWorthValuation adalah nilai item pada satu tanggal. Satu item hanya punya satu
+// penilaian per tanggal; mencatat ulang tanggal yang sama menimpa nilainya.
+type NetWorthValuation struct {
+	ID     uint      `json:"id" example:"1" gorm:"primaryKey"`
+	ItemID uint      `json:"item_id" example:"1" gorm:"uniqueIndex:idx_valuation_item_date"`
+	Date   time.Time `json:"date" gorm:"type:date;uniqueIndex:idx_valuation_item_date" example:"2025-08-31T00:00:00Z"`
+	Value  float64   `json:"value" example:"12500000"`
+	Note   string    `json:"note,omitempty" example:"Harga buyback"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ValueAt mengembalikan nilai item per tanggal date (00:00 UTC mewakili tanggal lokal):
+// penilaian terakhir pada atau sebelum date, 0 jika belum pernah dinilai atau sudah ditutup.
+// Valuations harus urut tanggal naik.
+func (item NetWorthItem) ValueAt(date time.Time) (float64, bool) {
+	if item.ClosedAt != nil && !date.Before(*item.ClosedAt) {
+		return 0, false
+	}
+	value, found := 0.0, false
+	for _, v := range item.Valuations {
+		if v.Date.After(date) {
+			break
+		}
+		value, found = v.Value, true
+	}
+	return value, found
+}
+
+// NetWorthPoint adalah posisi kekayaan bersih di akhir satu bulan
+type NetWorthPoint struct {
+	Month       string  `json:"month" example:"2025-08"`
+	Date        string  `json:"date" example:"2025-08-31"`
+	Cash        float64 `json:"cash" example:"8500000"`
+	Assets      float64 `json:"assets" example:"350000000"`
+	Liabilities float64 `json:"liabilities" example:"210000000"`
+	NetWorth    float64 `json:"net_worth" example:"148500000"`
+}
+
+// NetWorthItemValue adalah nilai satu item pada tanggal terakhir respon
+type NetWorthItemValue struct {
+	ID       uint    `json:"id" example:"1"`
+	Kind     string  `json:"kind" example:"asset"`
+	Category string  `json:"category" example:"emas"`
+	Name     string  `json:"name" example:"Emas Antam 10 gram"`
+	Value    float64 `json:"value" example:"12500000"`
+	ValuedAt string  `json:"valued_at,omitempty" example:"2025-08-31"`
+}
+
+// NetWorthResponse adalah hasil /api/net-worth. Cash adalah saldo kumulatif transaksi
+// approved (sama dengan saldo di GetDashboard).
+type NetWorthResponse struct {
+	Current NetWorthPoint       `json:"current"`
+	Points  []NetWorthPoint     `json:"points"`
+	Items   []NetWorthItemValue `json:"items"`
+}
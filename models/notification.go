@@ -0,0 +1,74 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Notification adalah satu pesan di inbox in-app milik user
+type Notification struct {
+	ID        uint       `json:"id" example:"1" gorm:"primaryKey"`
+	UserID    string     `json:"user_id" example:"budi" gorm:"index"`
+	EventType string     `json:"event_type" example:"bill.due"`
+	Title     string     `json:"title" example:"Tagihan Listrik PLN jatuh tempo hari ini"`
+	Body      string     `json:"body" example:"Perkiraan Rp450.000, jatuh tempo 2025-09-20."`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index"`
+}
+
+// NotificationInbox adalah isi inbox beserta jumlah yang belum dibaca
+type NotificationInbox struct {
+	Unread int64          `json:"unread" example:"3"`
+	Items  []Notification `json:"items"`
+}
+
+// NotificationPreference adalah pengaturan notifikasi satu user: alamat tujuan tiap channel
+// dan event mana dikirim ke channel mana. Workspace dan Role disalin dari identitas user saat
+// terakhir menyimpan, dipakai untuk menentukan event mana yang boleh diterima.
+type NotificationPreference struct {
+	ID         uint                `json:"-" gorm:"primaryKey"`
+	UserID     string              `json:"user_id" example:"budi" gorm:"uniqueIndex"`
+	Workspace  string              `json:"workspace,omitempty" example:"keluarga-budi" gorm:"index"`
+	Role       string              `json:"role,omitempty" example:"manager"`
+	Email      string              `json:"email,omitempty" example:"budi@example.com"`
+	WebhookURL string              `json:"webhook_url,omitempty" example:"https://hooks.example.com/budi"`
+	Routes     []NotificationRoute `json:"-" gorm:"foreignKey:PreferenceID"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// NotificationRoute mengirim satu tipe event ke satu channel
+type NotificationRoute struct {
+	ID           uint   `gorm:"primaryKey"`
+	PreferenceID uint   `gorm:"uniqueIndex:idx_notification_route"`
+	Event        string `gorm:"uniqueIndex:idx_notification_route;index"`
+	Channel      string `gorm:"uniqueIndex:idx_notification_route"`
+}
+
+// ChannelsFor mengembalikan channel tujuan untuk tipe event
+func (p NotificationPreference) ChannelsFor(event string) []string {
+	var list []string
+	for _, r := range p.Routes {
+		if r.Event == event {
+			list = append(list, r.Channel)
+		}
+	}
+	return list
+}
+
+// RouteMap mengembalikan routes dalam bentuk event -> channel
+func (p NotificationPreference) RouteMap() map[string][]string {
+	routes := map[string][]string{}
+	for _, r := range p.Routes {
+		routes[r.Event] = append(routes[r.Event], r.Channel)
+	}
+	for _, channels := range routes {
+		sort.Strings(channels)
+	}
+	return routes
+}
+
+// NotificationPreferenceResponse adalah preferensi yang dikirim ke client
+type NotificationPreferenceResponse struct {
+	NotificationPreference
+	Routes map[string][]string `json:"routes"`
+}
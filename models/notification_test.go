@@ -0,0 +1,33 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNotificationPreferenceRoutes(t *testing.T) {
+	p := NotificationPreference{Routes: []NotificationRoute{
+		{Event: "bill.due", Channel: "inbox"},
+		{Event: "bill.due", Channel: "email"},
+		{Event: "anomaly.detected", Channel: "webhook"},
+	}}
+
+	tests := []struct {
+		event string
+		want  []string
+	}{
+		{"bill.due", []string{"inbox", "email"}},
+		{"anomaly.detected", []string{"webhook"}},
+		{"bill.paid", nil},
+	}
+	for _, tt := range tests {
+		if got := p.ChannelsFor(tt.event); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ChannelsFor(%q) = %v, want %v", tt.event, got, tt.want)
+		}
+	}
+
+	want := map[string][]string{"bill.due": {"email", "inbox"}, "anomaly.detected": {"webhook"}}
+	if got := p.RouteMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RouteMap() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// Frekuensi recurring transaction
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+func IsValidFrequency(f string) bool {
+	switch f {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		return true
+	}
+	return false
+}
+
+// RecurringTransaction adalah template transaksi yang berulang (gaji, sewa, langganan).
+// Dipakai forecast sebagai arus kas yang sudah pasti. Transaksi nyata yang merupakan
+// realisasinya bisa diberi recurring_id supaya tidak dihitung lagi sebagai pengeluaran variabel.
+type RecurringTransaction struct {
+	ID          uint    `json:"id" example:"1" gorm:"primaryKey"`
+	Type        string  `json:"type" example:"pengeluaran"`
+	Amount      float64 `json:"amount" example:"1500000"`
+	Description string  `json:"description" example:"Sewa kos"`
+	Category    string  `json:"category" example:"tempat tinggal"`
+	Account     string  `json:"account" example:"bca"`
+	Payee       string  `json:"payee" example:"Bu Kos"`
+
+	// Berulang tiap Interval x Frequency sejak StartDate, sampai EndDate (inklusif) jika ada.
+	// Untuk monthly, tanggal 29-31 jatuh di akhir bulan pada bulan yang lebih pendek.
+	Frequency string     `json:"frequency" example:"monthly"`
+	Interval  int        `json:"interval" example:"1" gorm:"default:1"`
+	StartDate time.Time  `json:"start_date" gorm:"type:date" example:"2025-08-01T00:00:00Z"`
+	EndDate   *time.Time `json:"end_date,omitempty" gorm:"type:date"`
+	IsActive  bool       `json:"is_active" gorm:"index" example:"true"`
+
+	CreatedBy string    `json:"created_by" example:"budi"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SignedAmount bernilai negatif untuk pengeluaran
+func (rt RecurringTransaction) SignedAmount() float64 {
+	if rt.Type == "pengeluaran" {
+		return -rt.Amount
+	}
+	return rt.Amount
+}
+
+// Occurrences mengembalikan tanggal kejadian dalam rentang from - to (inklusif).
+// Semua tanggal berupa jam 00:00 UTC yang mewakili tanggal lokal.
+func (rt RecurringTransaction) Occurrences(from, to time.Time) []time.Time {
+	interval := max(rt.Interval, 1)
+	start := time.Date(rt.StartDate.Year(), rt.StartDate.Month(), rt.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	if rt.EndDate != nil {
+		end := time.Date(rt.EndDate.Year(), rt.EndDate.Month(), rt.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+		if end.Before(to) {
+			to = end
+		}
+	}
+
+	var dates []time.Time
+	for n := 0; ; n++ {
+		var d time.Time
+		switch rt.Frequency {
+		case FrequencyDaily:
+			d = start.AddDate(0, 0, n*interval)
+		case FrequencyWeekly:
+			d = start.AddDate(0, 0, 7*n*interval)
+		case FrequencyMonthly:
+			d = addMonthsClamped(start, n*interval)
+		case FrequencyYearly:
+			d = addMonthsClamped(start, 12*n*interval)
+		default:
+			return nil
+		}
+		if d.After(to) {
+			return dates
+		}
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+	}
+}
+
+// addMonthsClamped seperti AddDate(0, months, 0) tapi tidak meluber ke bulan berikutnya
+// (31 Jan + 1 bulan = 28/29 Feb, bukan 3 Mar).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
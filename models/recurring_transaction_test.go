@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func utcDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      time.Time
+		months int
+		want   time.Time
+	}{
+		{"end of january to february", utcDate(2025, 1, 31), 1, utcDate(2025, 2, 28)},
+		{"end of january to leap february", utcDate(2024, 1, 31), 1, utcDate(2024, 2, 29)},
+		{"clamp does not carry over", utcDate(2025, 1, 31), 2, utcDate(2025, 3, 31)},
+		{"to thirty day month", utcDate(2025, 3, 31), 1, utcDate(2025, 4, 30)},
+		{"backwards", utcDate(2025, 3, 31), -1, utcDate(2025, 2, 28)},
+		{"across year", utcDate(2025, 12, 15), 1, utcDate(2026, 1, 15)},
+		{"leap day next year", utcDate(2024, 2, 29), 12, utcDate(2025, 2, 28)},
+		{"zero months", utcDate(2025, 8, 31), 0, utcDate(2025, 8, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addMonthsClamped(tt.t, tt.months); !got.Equal(tt.want) {
+				t.Errorf("addMonthsClamped(%s, %d) = %s, want %s",
+					tt.t.Format("2006-01-02"), tt.months, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestRecurringTransactionOccurrences(t *testing.T) {
+	end := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name     string
+		rt       RecurringTransaction
+		from, to time.Time
+		want     []time.Time
+	}{
+		{
+			name: "monthly clamps to month end",
+			rt:   RecurringTransaction{Frequency: FrequencyMonthly, StartDate: utcDate(2025, 1, 31)},
+			from: utcDate(2025, 1, 1), to: utcDate(2025, 4, 30),
+			want: []time.Time{utcDate(2025, 1, 31), utcDate(2025, 2, 28), utcDate(2025, 3, 31), utcDate(2025, 4, 30)},
+		},
+		{
+			name: "every two months from inside the range",
+			rt:   RecurringTransaction{Frequency: FrequencyMonthly, Interval: 2, StartDate: utcDate(2025, 1, 10)},
+			from: utcDate(2025, 2, 1), to: utcDate(2025, 9, 30),
+			want: []time.Time{utcDate(2025, 3, 10), utcDate(2025, 5, 10), utcDate(2025, 7, 10), utcDate(2025, 9, 10)},
+		},
+		{
+			name: "end date is inclusive",
+			rt: RecurringTransaction{Frequency: FrequencyDaily, StartDate: utcDate(2025, 8, 30),
+				EndDate: end(time.Date(2025, 9, 1, 17, 0, 0, 0, time.UTC))},
+			from: utcDate(2025, 8, 1), to: utcDate(2025, 9, 30),
+			want: []time.Time{utcDate(2025, 8, 30), utcDate(2025, 8, 31), utcDate(2025, 9, 1)},
+		},
+		{
+			name: "biweekly",
+			rt:   RecurringTransaction{Frequency: FrequencyWeekly, Interval: 2, StartDate: utcDate(2025, 9, 1)},
+			from: utcDate(2025, 9, 1), to: utcDate(2025, 9, 30),
+			want: []time.Time{utcDate(2025, 9, 1), utcDate(2025, 9, 15), utcDate(2025, 9, 29)},
+		},
+		{
+			name: "yearly on leap day",
+			rt:   RecurringTransaction{Frequency: FrequencyYearly, StartDate: utcDate(2024, 2, 29)},
+			from: utcDate(2024, 1, 1), to: utcDate(2028, 12, 31),
+			want: []time.Time{utcDate(2024, 2, 29), utcDate(2025, 2, 28), utcDate(2026, 2, 28), utcDate(2027, 2, 28), utcDate(2028, 2, 29)},
+		},
+		{
+			name: "starts after range",
+			rt:   RecurringTransaction{Frequency: FrequencyMonthly, StartDate: utcDate(2026, 1, 1)},
+			from: utcDate(2025, 1, 1), to: utcDate(2025, 12, 31),
+		},
+		{
+			name: "unknown frequency",
+			rt:   RecurringTransaction{Frequency: "hourly", StartDate: utcDate(2025, 1, 1)},
+			from: utcDate(2025, 1, 1), to: utcDate(2025, 12, 31),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rt.Occurrences(tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %s, want %s", i, got[i].Format("2006-01-02"), tt.want[i].Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,12 @@ type TransactionResponse struct {
 	Category      string  `json:"category"`
 	Description   string  `json:"description"`
 	Amount        float64 `json:"amount"`
+	Account       string  `json:"account"`
+	Payee         string  `json:"payee"`
+	Status        string  `json:"status"`
+	CreatedBy     string  `json:"created_by"`
 	TransactionAt string  `json:"transaction_at"`
-	CreatedAt     string  `json:"created_at"` // string untuk tampil WIB
+	CreatedAt     string  `json:"created_at"` // string dalam zona aplikasi
+	DebtID        *uint   `json:"debt_id,omitempty"`
+	InstallmentID *uint   `json:"installment_id,omitempty"`
 }
@@ -6,6 +6,14 @@ import (
 	"github.com/lib/pq"
 )
 
+// Status approval transaksi
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+)
+
 // Transaction mewakili entitas transaksi keuangan
 type Transaction struct {
 	ID            uint           `json:"id" example:"1" gorm:"primaryKey"`
@@ -14,9 +22,25 @@ type Transaction struct {
 	Description   string         `json:"description" example:"Beli Mie Gacoan"`
 	Category      string         `json:"category" example:"makanan"`
 	Categories    pq.StringArray `json:"categories" gorm:"type:text[]" swaggertype:"array,string" example:"[\"makanan\",\"jajan\"]"`
+	Account       string         `json:"account" example:"bca" gorm:"index"`
+	Payee         string         `json:"payee" example:"Mie Gacoan" gorm:"index"`
 	TransactionAt time.Time      `json:"transaction_at" example:"2025-08-07T12:00:00Z"`
 	CreatedAt     time.Time      `json:"created_at" example:"2025-08-07T12:00:00Z"`
 
+	// Approval workflow. Transaksi lama otomatis dianggap approved.
+	Status          string     `json:"status" example:"approved" gorm:"default:approved;index"`
+	CreatedBy       string     `json:"created_by" example:"budi"`
+	ApprovedBy      string     `json:"approved_by,omitempty" example:"manager-1"`
+	ApprovalComment string     `json:"approval_comment,omitempty" example:"Oke, sesuai budget"`
+	DecidedAt       *time.Time `json:"decided_at,omitempty" example:"2025-08-08T09:00:00Z"`
+
+	// Diisi jika transaksi adalah realisasi recurring transaction
+	RecurringID *uint `json:"recurring_id,omitempty" example:"1" gorm:"index"`
+	// Diisi jika transaksi adalah pencairan atau pembayaran hutang piutang (diatur lewat /api/debts)
+	DebtID *uint `json:"debt_id,omitempty" example:"1" gorm:"index"`
+	// Diisi jika transaksi adalah pembayaran cicilan (dicocokkan otomatis atau lewat /api/installments)
+	InstallmentID *uint `json:"installment_id,omitempty" example:"1" gorm:"index"`
+
 	// View-only field for Swagger or API response
 	CategoriesView []string `json:"categories_view" gorm:"-"`
 }
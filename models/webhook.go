@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Status pengiriman webhook
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead" // retry habis, masuk dead-letter
+)
+
+// WebhookSubscription adalah URL milik tool luar (bot Discord, sync spreadsheet, ...)
+// yang dikirimi event. Events berisi tipe event ("transaction.created"), wildcard per
+// grup ("campaign.*") atau "*" untuk semua. Subscription hanya menerima event dari
+// Workspace-nya; subscription lama tanpa Workspace hanya menerima event tanpa workspace.
+type WebhookSubscription struct {
+	ID          uint           `json:"id" example:"1" gorm:"primaryKey"`
+	URL         string         `json:"url" example:"https://example.com/hooks/cashflow"`
+	Secret      string         `json:"-"`
+	Events      pq.StringArray `json:"events" gorm:"type:text[]" swaggertype:"array,string" example:"[\"transaction.*\"]"`
+	Workspace   string         `json:"workspace,omitempty" example:"keluarga-budi" gorm:"index"`
+	Description string         `json:"description,omitempty" example:"Bot Discord keluarga"`
+	IsActive    bool           `json:"is_active" example:"true" gorm:"index"`
+	CreatedBy   string         `json:"created_by,omitempty" example:"budi"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Matches mengecek apakah tipe event masuk filter subscription
+func (s WebhookSubscription) Matches(eventType, workspace string) bool {
+	if s.Workspace != workspace {
+		return false
+	}
+	for _, pattern := range s.Events {
+		if pattern == "*" || pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, ".*"); ok && strings.HasPrefix(eventType, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery adalah satu event yang harus dikirim ke satu subscription.
+// Payload disimpan apa adanya supaya retry dan redeliver mengirim body yang sama persis.
+type WebhookDelivery struct {
+	ID             uint       `json:"id" example:"10" gorm:"primaryKey"`
+	SubscriptionID uint       `json:"subscription_id" example:"1" gorm:"index"`
+	EventType      string     `json:"event_type" example:"transaction.created"`
+	Payload        string     `json:"payload" gorm:"type:text"`
+	Status         string     `json:"status" example:"pending" gorm:"index"`
+	Attempts       int        `json:"attempts" example:"2"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index"`
+	LastStatusCode int        `json:"last_status_code,omitempty" example:"502"`
+	LastError      string     `json:"last_error,omitempty" example:"HTTP 502"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+
+	Log []WebhookAttempt `json:"log,omitempty" gorm:"foreignKey:DeliveryID"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookAttempt adalah log satu percobaan kirim
+type WebhookAttempt struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	DeliveryID uint      `json:"delivery_id" gorm:"index"`
+	StatusCode int       `json:"status_code,omitempty" example:"200"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms" example:"120"`
+	CreatedAt  time.Time `json:"created_at"`
+}
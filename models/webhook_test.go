@@ -0,0 +1,35 @@
+package models
+
+import "testing"
+
+func TestWebhookSubscriptionMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    []string
+		subWS     string
+		eventType string
+		eventWS   string
+		want      bool
+	}{
+		{"wildcard all", []string{"*"}, "ws", "campaign.created", "ws", true},
+		{"group wildcard", []string{"transaction.*"}, "ws", "transaction.deleted", "ws", true},
+		{"group wildcard other group", []string{"transaction.*"}, "ws", "campaign.created", "ws", false},
+		{"group wildcard needs dot", []string{"bill.*"}, "ws", "billing.due", "ws", false},
+		{"exact", []string{"bill.due"}, "ws", "bill.due", "ws", true},
+		{"exact other type", []string{"bill.due"}, "ws", "bill.paid", "ws", false},
+		{"one of many", []string{"bill.due", "campaign.*"}, "ws", "campaign.updated", "ws", true},
+		{"other workspace", []string{"*"}, "ws", "bill.due", "other", false},
+		{"workspace event to empty subscription", []string{"*"}, "", "bill.due", "ws", false},
+		{"empty subscription and event", []string{"*"}, "", "bill.due", "", true},
+		{"empty event to workspace subscription", []string{"*"}, "ws", "bill.due", "", false},
+		{"no events", nil, "ws", "bill.due", "ws", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := WebhookSubscription{Events: tt.events, Workspace: tt.subWS}
+			if got := s.Matches(tt.eventType, tt.eventWS); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.eventType, tt.eventWS, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/models"
+)
+
+const sendTimeout = 10 * time.Second
+
+// inboxChannel menyimpan notifikasi ke inbox in-app
+type inboxChannel struct{}
+
+func (inboxChannel) Ready(models.NotificationPreference) error { return nil }
+
+func (inboxChannel) Send(to models.NotificationPreference, m Message) error {
+	return db.CreateNotification(&models.Notification{
+		UserID:    to.UserID,
+		EventType: m.Event,
+		Title:     m.Title,
+		Body:      m.Body,
+	})
+}
+
+// emailChannel mengirim email teks lewat SMTP. Konfigurasi dibaca dari env setiap kirim:
+// SMTP_HOST, SMTP_PORT (default 25), SMTP_USERNAME / SMTP_PASSWORD (opsional) dan SMTP_FROM.
+type emailChannel struct{}
+
+func (emailChannel) Ready(to models.NotificationPreference) error {
+	if to.Email == "" {
+		return errors.New("email wajib diisi untuk channel email")
+	}
+	return nil
+}
+
+func (emailChannel) Send(to models.NotificationPreference, m Message) error {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return errors.New("SMTP_HOST belum diatur")
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "25"
+	}
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "cash-flow@localhost"
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), sendTimeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(sendTimeout))
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	// Mail catcher lokal biasanya tanpa TLS dan tanpa auth
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if user := os.Getenv("SMTP_USERNAME"); user != "" {
+		if err := c.Auth(smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Email); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(emailMessage(from, to.Email, m)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func emailMessage(from, to string, m Message) []byte {
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(m.Title)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	qp.Write([]byte(m.Body))
+	qp.Close()
+	return buf.Bytes()
+}
+
+// webhookChannel mengirim pesan sebagai POST JSON ke URL milik user. Field text berisi
+// judul dan isi supaya bisa langsung dipakai incoming webhook chat (Slack, Mattermost).
+type webhookChannel struct{}
+
+var webhookClient = &http.Client{Timeout: sendTimeout}
+
+func (webhookChannel) Ready(to models.NotificationPreference) error {
+	if to.WebhookURL == "" {
+		return errors.New("webhook_url wajib diisi untuk channel webhook")
+	}
+	return nil
+}
+
+func (webhookChannel) Send(to models.NotificationPreference, m Message) error {
+	body, err := json.Marshal(struct {
+		Message
+		UserID string `json:"user_id"`
+		Text   string `json:"text"`
+	}{m, to.UserID, m.Title + "\n" + m.Body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, to.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cash-flow-go-notifications/1")
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 512))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package notifications
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"cash-flow-go/models"
+)
+
+func TestEmailMessage(t *testing.T) {
+	m := Message{Title: "Tagihan\r\nBcc: korban@example.com Listrik — jatuh tempo", Body: "Perkiraan Rp450.000, jatuh tempo 2025-09-20. " + strings.Repeat("x", 100)}
+	raw := emailMessage("cash-flow@localhost", "budi@example.com", m)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if got := msg.Header.Get("Bcc"); got != "" {
+		t.Errorf("subject newline injected a Bcc header: %q", got)
+	}
+	if got := msg.Header.Get("To"); got != "budi@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("decode subject: %v", err)
+	}
+	if want := "Tagihan  Bcc: korban@example.com Listrik — jatuh tempo"; subject != want {
+		t.Errorf("Subject = %q, want %q", subject, want)
+	}
+	if got := msg.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q", got)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if string(body) != m.Body {
+		t.Errorf("body = %q, want %q", body, m.Body)
+	}
+}
+
+// fakeSMTP adalah server SMTP minimal (seperti mail catcher) yang mencatat satu sesi
+type fakeSMTP struct {
+	addr     string
+	auth     chan string
+	from, to chan string
+	data     chan string
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTP{addr: ln.Addr().String(), auth: make(chan string, 1),
+		from: make(chan string, 1), to: make(chan string, 1), data: make(chan string, 1)}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost fake SMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			case "AUTH":
+				s.auth <- strings.TrimPrefix(line, "AUTH PLAIN ")
+				reply("235 ok")
+			case "MAIL":
+				s.from <- line
+				reply("250 ok")
+			case "RCPT":
+				s.to <- line
+				reply("250 ok")
+			case "DATA":
+				reply("354 end with .")
+				var buf strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					buf.WriteString(l)
+				}
+				s.data <- buf.String()
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return s
+}
+
+func TestEmailChannelSend(t *testing.T) {
+	srv := startFakeSMTP(t)
+	host, port, _ := net.SplitHostPort(srv.addr)
+	t.Setenv("SMTP_HOST", host)
+	t.Setenv("SMTP_PORT", port)
+	t.Setenv("SMTP_FROM", "kas@example.com")
+	t.Setenv("SMTP_USERNAME", "kas")
+	t.Setenv("SMTP_PASSWORD", "rahasia")
+
+	to := models.NotificationPreference{UserID: "budi", Email: "budi@example.com"}
+	m := Message{Event: TestEvent, Title: "Tes notifikasi", Body: "Halo"}
+	if err := (emailChannel{}).Send(to, m); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	auth, _ := base64.StdEncoding.DecodeString(<-srv.auth)
+	if string(auth) != "\x00kas\x00rahasia" {
+		t.Errorf("AUTH PLAIN = %q", auth)
+	}
+	if got := <-srv.from; got != "MAIL FROM:<kas@example.com>" {
+		t.Errorf("MAIL = %q", got)
+	}
+	if got := <-srv.to; got != "RCPT TO:<budi@example.com>" {
+		t.Errorf("RCPT = %q", got)
+	}
+	data := <-srv.data
+	if !strings.Contains(data, "Subject: Tes notifikasi\r\n") || !strings.HasSuffix(data, "\r\n\r\nHalo\r\n") {
+		t.Errorf("DATA = %q", data)
+	}
+}
+
+func TestEmailChannelNeedsHost(t *testing.T) {
+	t.Setenv("SMTP_HOST", "")
+	err := (emailChannel{}).Send(models.NotificationPreference{Email: "budi@example.com"}, Message{})
+	if err == nil || !strings.Contains(err.Error(), "SMTP_HOST") {
+		t.Errorf("Send without SMTP_HOST error = %v", err)
+	}
+}
+
+func TestWebhookChannelSend(t *testing.T) {
+	var got map[string]interface{}
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	to := models.NotificationPreference{UserID: "budi", WebhookURL: srv.URL}
+	m := Message{Event: "bill.due", Title: "Tagihan Listrik PLN jatuh tempo hari ini", Body: "Nominal Rp450.000."}
+	if err := (webhookChannel{}).Send(to, m); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got["user_id"] != "budi" || got["event"] != "bill.due" || got["text"] != m.Title+"\n"+m.Body {
+		t.Errorf("payload = %v", got)
+	}
+
+	status = http.StatusBadGateway
+	if err := (webhookChannel{}).Send(to, m); err == nil || err.Error() != "HTTP 502" {
+		t.Errorf("Send to failing webhook error = %v, want HTTP 502", err)
+	}
+}
@@ -0,0 +1,186 @@
+// Package notifications memberi tahu user tentang event penting (tagihan jatuh tempo,
+// anomali, transaksi menunggu approval) lewat channel yang dipilih di preferensi masing-masing:
+// inbox in-app, email (SMTP) atau webhook. Channel baru cukup didaftarkan lewat Register.
+package notifications
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+// Nama channel bawaan
+const (
+	ChannelInbox   = "inbox"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// TestEvent adalah tipe pesan yang dikirim oleh SendTest
+const TestEvent = "notification.test"
+
+// roleManager sama dengan role manager di header X-User-Role
+const roleManager = "manager"
+
+// Events adalah tipe event yang bisa diatur di preferensi notifikasi
+var Events = []string{
+	events.BillUpcoming, events.BillDue, events.BillOverdue, events.BillPaid,
+	events.AnomalyDetected, events.ApprovalRequested,
+}
+
+// Message adalah notifikasi yang sudah dirender, sama untuk semua channel
+type Message struct {
+	Event string      `json:"event"`
+	Title string      `json:"title"`
+	Body  string      `json:"body"`
+	Data  interface{} `json:"data,omitempty"`
+	At    time.Time   `json:"at"`
+}
+
+// Channel mengirim pesan ke satu user
+type Channel interface {
+	// Ready mengecek apakah preferensi user punya tujuan untuk channel ini (misal alamat email)
+	Ready(to models.NotificationPreference) error
+	Send(to models.NotificationPreference, m Message) error
+}
+
+var channels = map[string]Channel{
+	ChannelInbox:   inboxChannel{},
+	ChannelEmail:   emailChannel{},
+	ChannelWebhook: webhookChannel{},
+}
+
+// Register menambah atau mengganti channel. Dipanggil sebelum Start.
+func Register(name string, c Channel) {
+	channels[name] = c
+}
+
+// Channels mengembalikan nama semua channel yang terdaftar
+func Channels() []string {
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsEvent mengecek apakah tipe event bisa diatur di preferensi
+func IsEvent(eventType string) bool {
+	for _, t := range Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Check memvalidasi routes preferensi: channel harus terdaftar dan siap dipakai
+func Check(p models.NotificationPreference) error {
+	for _, r := range p.Routes {
+		if !IsEvent(r.Event) {
+			return fmt.Errorf("event %q tidak bisa dipakai untuk notifikasi", r.Event)
+		}
+		c, ok := channels[r.Channel]
+		if !ok {
+			return fmt.Errorf("channel %q tidak dikenal", r.Channel)
+		}
+		if err := c.Ready(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start mendengarkan event bus dan meneruskan event ke channel setiap user yang memintanya
+func Start() {
+	sub := events.Default.Subscribe(func(e events.Event) bool { return IsEvent(e.Type) }, 256)
+	go func() {
+		for e := range sub.C {
+			if sub.Lagged() {
+				log.Println("notifikasi: event bus penuh, sebagian notifikasi tidak terkirim")
+			}
+			if err := dispatch(e); err != nil {
+				log.Printf("notifikasi: gagal memproses event %s: %v", e.Type, err)
+			}
+		}
+	}()
+}
+
+// receives menentukan apakah user boleh menerima event. Permintaan approval hanya untuk
+// manager di workspace yang sama selain pengaju; event global (anomali) untuk semua, sama
+// dengan SSE dan webhook; sisanya untuk workspace yang sama, atau user yang sama jika tidak
+// memakai workspace.
+func receives(p models.NotificationPreference, e events.Event) bool {
+	switch {
+	case e.Type == events.ApprovalRequested:
+		return p.Role == roleManager && p.UserID != e.UserID && p.Workspace == e.Workspace
+	case e.Global():
+		return true
+	case e.Workspace != "":
+		return p.Workspace == e.Workspace
+	default:
+		return p.Workspace == "" && p.UserID == e.UserID
+	}
+}
+
+func dispatch(e events.Event) error {
+	prefs, err := db.NotificationRecipients(e.Type)
+	if err != nil {
+		return err
+	}
+
+	m := render(e)
+	for _, p := range prefs {
+		if !receives(p, e) {
+			continue
+		}
+		for _, name := range p.ChannelsFor(e.Type) {
+			go func(p models.NotificationPreference, name string) {
+				if err := send(name, p, m); err != nil {
+					log.Printf("notifikasi: %s ke %s gagal: %v", name, p.UserID, err)
+				}
+			}(p, name)
+		}
+	}
+	return nil
+}
+
+func send(name string, p models.NotificationPreference, m Message) error {
+	c, ok := channels[name]
+	if !ok {
+		return fmt.Errorf("channel %q tidak dikenal", name)
+	}
+	if err := c.Ready(p); err != nil {
+		return err
+	}
+	return c.Send(p, m)
+}
+
+// SendTest mengirim pesan uji ke setiap channel yang dipakai preferensi user dan
+// mengembalikan hasilnya per channel ("ok" atau pesan error)
+func SendTest(p models.NotificationPreference) map[string]string {
+	m := Message{
+		Event: TestEvent,
+		Title: "Tes notifikasi",
+		Body:  "Jika pesan ini sampai, channel notifikasi sudah benar.",
+		At:    time.Now(),
+	}
+
+	result := map[string]string{}
+	for _, r := range p.Routes {
+		if _, done := result[r.Channel]; done {
+			continue
+		}
+		result[r.Channel] = "ok"
+		if err := send(r.Channel, p, m); err != nil {
+			result[r.Channel] = err.Error()
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package notifications
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+func TestReceives(t *testing.T) {
+	manager := models.NotificationPreference{UserID: "mira", Role: roleManager, Workspace: "ws"}
+	member := models.NotificationPreference{UserID: "ani", Workspace: "ws"}
+	personal := models.NotificationPreference{UserID: "budi"}
+
+	tests := []struct {
+		name  string
+		pref  models.NotificationPreference
+		event events.Event
+		want  bool
+	}{
+		{"approval to manager in workspace", manager, events.Event{Type: events.ApprovalRequested, UserID: "budi", Workspace: "ws"}, true},
+		{"approval to non-manager", member, events.Event{Type: events.ApprovalRequested, UserID: "budi", Workspace: "ws"}, false},
+		{"approval to manager in other workspace", manager, events.Event{Type: events.ApprovalRequested, UserID: "budi", Workspace: "other"}, false},
+		{"approval not sent back to submitter", manager, events.Event{Type: events.ApprovalRequested, UserID: "mira", Workspace: "ws"}, false},
+		{"global anomaly to workspace member", member, events.Event{Type: events.AnomalyDetected}, true},
+		{"global anomaly to personal user", personal, events.Event{Type: events.AnomalyDetected}, true},
+		{"bill in same workspace", member, events.Event{Type: events.BillDue, UserID: "budi", Workspace: "ws"}, true},
+		{"bill in other workspace", member, events.Event{Type: events.BillDue, UserID: "budi", Workspace: "other"}, false},
+		{"personal bill to owner", personal, events.Event{Type: events.BillDue, UserID: "budi"}, true},
+		{"personal bill to other user", member, events.Event{Type: events.BillDue, UserID: "budi"}, false},
+		{"workspace bill to personal user", personal, events.Event{Type: events.BillDue, UserID: "budi", Workspace: "ws"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := receives(tt.pref, tt.event); got != tt.want {
+				t.Errorf("receives(%+v) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	route := func(event, channel string) []models.NotificationRoute {
+		return []models.NotificationRoute{{Event: event, Channel: channel}}
+	}
+	tests := []struct {
+		name    string
+		pref    models.NotificationPreference
+		wantErr string
+	}{
+		{"inbox", models.NotificationPreference{Routes: route(events.BillDue, ChannelInbox)}, ""},
+		{"email with address", models.NotificationPreference{Email: "budi@example.com", Routes: route(events.BillDue, ChannelEmail)}, ""},
+		{"email without address", models.NotificationPreference{Routes: route(events.BillDue, ChannelEmail)}, "email wajib diisi"},
+		{"webhook without url", models.NotificationPreference{Routes: route(events.AnomalyDetected, ChannelWebhook)}, "webhook_url wajib diisi"},
+		{"unknown channel", models.NotificationPreference{Routes: route(events.BillDue, "sms")}, `channel "sms" tidak dikenal`},
+		{"event not configurable", models.NotificationPreference{Routes: route(events.TransactionCreated, ChannelInbox)}, "tidak bisa dipakai"},
+		{"no routes", models.NotificationPreference{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.pref)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Check() error = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	bill := models.UpcomingBill{Name: "Listrik PLN", Period: "2025-09", DueDate: "2025-09-20", DaysUntil: 3, Amount: 450000}
+	estimated := bill
+	estimated.Estimated = true
+
+	tests := []struct {
+		name      string
+		event     events.Event
+		wantTitle string
+		wantBody  string
+	}{
+		{"upcoming", events.Event{Type: events.BillUpcoming, Data: bill},
+			"Tagihan Listrik PLN jatuh tempo 3 hari lagi", "Nominal Rp450.000, jatuh tempo 2025-09-20."},
+		{"due estimated", events.Event{Type: events.BillDue, Data: estimated},
+			"Tagihan Listrik PLN jatuh tempo hari ini", "Perkiraan Rp450.000, jatuh tempo 2025-09-20."},
+		{"overdue", events.Event{Type: events.BillOverdue, Data: bill},
+			"Tagihan Listrik PLN lewat jatuh tempo", "Nominal Rp450.000, belum dibayar sejak 2025-09-20."},
+		{"paid", events.Event{Type: events.BillPaid, Data: bill},
+			"Tagihan Listrik PLN periode 2025-09 sudah dibayar", "Dibayar Rp450.000."},
+		{"anomaly", events.Event{Type: events.AnomalyDetected, Data: models.Anomaly{Explanation: "Pengeluaran makanan 3x rata-rata"}},
+			"Pengeluaran tidak biasa", "Pengeluaran makanan 3x rata-rata"},
+		{"approval", events.Event{Type: events.ApprovalRequested, Data: models.TransactionResponse{
+			CreatedBy: "budi", Amount: 1250000, Description: "Tiket pesawat", Category: "perjalanan"}},
+			"Pengeluaran menunggu approval", "budi mengajukan Rp1.250.000 untuk Tiket pesawat (perjalanan)."},
+		{"unknown data", events.Event{Type: "custom.event", Data: 42}, "custom.event", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.At = time.Date(2025, 9, 17, 8, 0, 0, 0, time.UTC)
+			m := render(tt.event)
+			if m.Title != tt.wantTitle || m.Body != tt.wantBody {
+				t.Errorf("render() = %q / %q, want %q / %q", m.Title, m.Body, tt.wantTitle, tt.wantBody)
+			}
+			if m.Event != tt.event.Type || !m.At.Equal(tt.event.At) {
+				t.Errorf("render() event/at = %s/%v, want %s/%v", m.Event, m.At, tt.event.Type, tt.event.At)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package notifications
+
+import (
+	"fmt"
+
+	"cash-flow-go/anomaly"
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+// render menyusun judul dan isi notifikasi dari data event
+func render(e events.Event) Message {
+	m := Message{Event: e.Type, Title: e.Type, Data: e.Data, At: e.At}
+
+	switch data := e.Data.(type) {
+	case models.UpcomingBill:
+		nominal := "Nominal " + anomaly.Rupiah(data.Amount)
+		if data.Estimated {
+			nominal = "Perkiraan " + anomaly.Rupiah(data.Amount)
+		}
+		switch e.Type {
+		case events.BillUpcoming:
+			m.Title = fmt.Sprintf("Tagihan %s jatuh tempo %d hari lagi", data.Name, data.DaysUntil)
+			m.Body = fmt.Sprintf("%s, jatuh tempo %s.", nominal, data.DueDate)
+		case events.BillDue:
+			m.Title = fmt.Sprintf("Tagihan %s jatuh tempo hari ini", data.Name)
+			m.Body = fmt.Sprintf("%s, jatuh tempo %s.", nominal, data.DueDate)
+		case events.BillOverdue:
+			m.Title = fmt.Sprintf("Tagihan %s lewat jatuh tempo", data.Name)
+			m.Body = fmt.Sprintf("%s, belum dibayar sejak %s.", nominal, data.DueDate)
+		case events.BillPaid:
+			m.Title = fmt.Sprintf("Tagihan %s periode %s sudah dibayar", data.Name, data.Period)
+			m.Body = fmt.Sprintf("Dibayar %s.", anomaly.Rupiah(data.Amount))
+		}
+
+	case models.Anomaly:
+		m.Title = "Pengeluaran tidak biasa"
+		m.Body = data.Explanation
+
+	case models.TransactionResponse:
+		m.Title = "Pengeluaran menunggu approval"
+		m.Body = fmt.Sprintf("%s mengajukan %s untuk %s (%s).",
+			data.CreatedBy, anomaly.Rupiah(data.Amount), data.Description, data.Category)
+	}
+	return m
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocal(t *testing.T) *Local {
+	t.Helper()
+	l := NewLocal(t.TempDir(), "http://localhost:8080", "rahasia")
+	if err := l.Put(context.Background(), "lampiran/nota mei.txt", strings.NewReader("0123456789"), -1, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	return l
+}
+
+// serveSigned meminta link lewat ServeHTTP, setelah link diubah oleh tamper
+func serveSigned(t *testing.T, l *Local, link string, tamper func(u *url.URL), header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse %s: %v", link, err)
+	}
+	if tamper != nil {
+		tamper(u)
+	}
+	req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	l.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLocalSignedURL(t *testing.T) {
+	l := newTestLocal(t)
+	ctx := context.Background()
+	link, err := l.SignedURL(ctx, "lampiran/nota mei.txt", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	if !strings.HasPrefix(link, "http://localhost:8080/files/lampiran/nota%20mei.txt?") {
+		t.Fatalf("SignedURL = %s", link)
+	}
+	other, _ := l.SignedURL(ctx, "lampiran/lain.txt", time.Minute)
+	otherSig := func() string { u, _ := url.Parse(other); return u.Query().Get("signature") }()
+
+	setQuery := func(name, value string) func(u *url.URL) {
+		return func(u *url.URL) {
+			q := u.Query()
+			q.Set(name, value)
+			u.RawQuery = q.Encode()
+		}
+	}
+	tests := []struct {
+		name   string
+		tamper func(u *url.URL)
+		want   int
+	}{
+		{"valid", nil, http.StatusOK},
+		{"tampered signature", setQuery("signature", strings.Repeat("0", 64)), http.StatusForbidden},
+		{"signature of another key", setQuery("signature", otherSig), http.StatusForbidden},
+		{"extended expiry", setQuery("expires", "9999999999"), http.StatusForbidden},
+		{"missing expiry", setQuery("expires", ""), http.StatusForbidden},
+		{"other key", func(u *url.URL) { u.Path = "/files/lampiran/lain.txt" }, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveSigned(t, l, link, tt.tamper, nil)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want == http.StatusOK && rec.Body.String() != "0123456789" {
+				t.Errorf("body = %q", rec.Body)
+			}
+		})
+	}
+}
+
+func TestLocalSignedURLExpired(t *testing.T) {
+	l := newTestLocal(t)
+	link, _ := l.SignedURL(context.Background(), "lampiran/nota mei.txt", -time.Second)
+	if rec := serveSigned(t, l, link, nil, nil); rec.Code != http.StatusForbidden {
+		t.Errorf("expired link status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestLocalSignedURLRange(t *testing.T) {
+	l := newTestLocal(t)
+	link, _ := l.SignedURL(context.Background(), "lampiran/nota mei.txt", time.Minute)
+	rec := serveSigned(t, l, link, nil, http.Header{"Range": {"bytes=2-4"}})
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "234" {
+		t.Errorf("range = %d %q, want 206 %q", rec.Code, rec.Body, "234")
+	}
+	if rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Error("missing nosniff header")
+	}
+}
+
+func TestLocalSignedURLMissingFile(t *testing.T) {
+	l := newTestLocal(t)
+	link, _ := l.SignedURL(context.Background(), "lampiran/hilang.txt", time.Minute)
+	if rec := serveSigned(t, l, link, nil, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("missing file status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestLocalPathStaysInDir(t *testing.T) {
+	l := newTestLocal(t)
+	ctx := context.Background()
+	if err := l.Put(ctx, "../../luar.txt", strings.NewReader("x"), -1, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	rc, err := l.Open(ctx, "luar.txt")
+	if err != nil {
+		t.Fatalf("traversal key was not kept inside dir: %v", err)
+	}
+	rc.Close()
+
+	if _, err := l.path("/"); err == nil {
+		t.Error("empty key accepted")
+	}
+	if err := l.Delete(ctx, "luar.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := l.Open(ctx, "luar.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete error = %v, want ErrNotFound", err)
+	}
+
+	rc, err = l.Open(ctx, "lampiran/nota mei.txt")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer rc.Close()
+	if b, _ := io.ReadAll(rc); string(b) != "0123456789" {
+		t.Errorf("Open = %q", b)
+	}
+}
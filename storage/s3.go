@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+type S3Config struct {
+	// Endpoint dipakai API untuk upload/hapus, misal http://minio:9000
+	Endpoint string
+	// PublicEndpoint dipakai untuk presigned URL yang dibuka browser,
+	// misal http://localhost:9000. Kosong = sama dengan Endpoint.
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+}
+
+// S3 adalah backend S3-compatible (AWS S3, MinIO, R2, dsb) dengan path-style URL
+// dan AWS Signature V4, tanpa dependensi SDK.
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	public   *url.URL
+	client   *http.Client
+}
+
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY dan S3_SECRET_KEY wajib diisi")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	public := endpoint
+	if cfg.PublicEndpoint != "" {
+		if public, err = url.Parse(strings.TrimRight(cfg.PublicEndpoint, "/")); err != nil {
+			return nil, err
+		}
+	}
+	return &S3{cfg: cfg, endpoint: endpoint, public: public, client: &http.Client{Timeout: 60 * time.Second}}, nil
+}
+
+func (s *S3) objectURL(base *url.URL, key string) *url.URL {
+	u := *base
+	u.Path = "/" + s.cfg.Bucket
+	u.RawPath = "/" + escapeKey(s.cfg.Bucket)
+	if key != "" {
+		u.Path += "/" + key
+		u.RawPath += "/" + escapeKey(key)
+	}
+	return &u
+}
+
+// EnsureBucket membuat bucket jika belum ada (berguna untuk MinIO lokal)
+func (s *S3) EnsureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, -1, "")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = s.do(ctx, http.MethodPut, "", nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return responseError(resp)
+	}
+	return nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		// S3 butuh Content-Length, jadi baca dulu ke memory
+		buf, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		r, size = bytes.NewReader(buf), int64(len(buf))
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, r, size, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, -1, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp.Body, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, -1, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// SignedURL membuat presigned GET URL (query string SigV4)
+func (s *S3) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return s.presign(s.objectURL(s.public, key), time.Now().UTC(), ttl), nil
+}
+
+// presign menambahkan query SigV4 ke u; waktu dipisah supaya bisa diuji dengan vektor AWS
+func (s *S3) presign(u *url.URL, now time.Time, ttl time.Duration) string {
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(amzDate)
+
+	q := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.cfg.AccessKey + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(ttl.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	query := canonicalQuery(q)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		query,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	signed := *u
+	signed.RawQuery = query + "&X-Amz-Signature=" + s.signature(amzDate, canonicalRequest)
+	return signed.String()
+}
+
+func (s *S3) do(ctx context.Context, method, key string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	u := s.objectURL(s.endpoint, key)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && body != nil {
+		req.ContentLength = size
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.signRequest(req, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// signRequest menambahkan header Authorization SigV4. Payload tidak ikut di-hash
+// (UNSIGNED-PAYLOAD) supaya upload bisa di-stream.
+func (s *S3) signRequest(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + unsignedPayload + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, s.scope(amzDate), signedHeaders, s.signature(amzDate, canonicalRequest),
+	))
+}
+
+func (s *S3) scope(amzDate string) string {
+	return amzDate[:8] + "/" + s.cfg.Region + "/s3/aws4_request"
+}
+
+func (s *S3) signature(amzDate, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + s.scope(amzDate) + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), amzDate[:8])
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery mengurutkan dan meng-encode query sesuai aturan SigV4 ("/" ikut di-encode)
+func canonicalQuery(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, escapeQuery(k)+"="+escapeQuery(params[k]))
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapeQuery(s string) string {
+	return strings.ReplaceAll(escapeKey(s), "/", "%2F")
+}
+
+func responseError(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage: S3 %s %s: %s", resp.Request.Method, resp.Status, strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header yang dikirim ke penerima
+const (
+	HeaderSignature = "X-CashFlow-Signature"
+	HeaderEvent     = "X-CashFlow-Event"
+	HeaderDelivery  = "X-CashFlow-Delivery"
+)
+
+// SignatureTolerance adalah selisih waktu maksimal yang diterima Verify (proteksi replay)
+const SignatureTolerance = 5 * time.Minute
+
+// NewSecret membuat secret acak untuk subscription baru
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Sign menghasilkan nilai header X-CashFlow-Signature: "t=<unix>,v1=<hex>", dengan
+// v1 = HMAC-SHA256(secret, "<unix>.<body>"). Timestamp ikut ditandatangani supaya
+// payload lama tidak bisa dikirim ulang oleh pihak lain.
+func Sign(secret string, at time.Time, body []byte) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac(secret, ts, body))
+}
+
+// Verify memeriksa header signature, dipakai penerima yang ditulis dalam Go
+func Verify(secret, header string, body []byte, now time.Time) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return errors.New("format signature tidak valid")
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > SignatureTolerance || d < -SignatureTolerance {
+		return errors.New("timestamp signature kedaluwarsa")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, mac(secret, ts, body)) {
+		return errors.New("signature tidak cocok")
+	}
+	return nil
+}
+
+func mac(secret, ts string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
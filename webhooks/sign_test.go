@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"json body", `{"id":1}`, "t=1700000000,v1=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8"},
+		{"empty body", "", "t=1700000000,v1=5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign("whsec_test", at, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec_test"
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+	header := Sign(secret, at, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr string
+	}{
+		{"valid", secret, header, body, at, ""},
+		{"valid within tolerance", secret, header, body, at.Add(SignatureTolerance), ""},
+		{"spaces after comma", secret, strings.Replace(header, ",", ", ", 1), body, at, ""},
+		{"tampered body", secret, header, []byte(`{"id":2}`), at, "tidak cocok"},
+		{"wrong secret", "whsec_other", header, body, at, "tidak cocok"},
+		{"expired", secret, header, body, at.Add(SignatureTolerance + time.Second), "kedaluwarsa"},
+		{"from the future", secret, header, body, at.Add(-SignatureTolerance - time.Second), "kedaluwarsa"},
+		{"missing v1", secret, "t=1700000000", body, at, "format"},
+		{"bad timestamp", secret, "t=abc,v1=00", body, at, "format"},
+		{"bad hex", secret, "t=1700000000,v1=zz", body, at, "tidak cocok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tt.now)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Verify error = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Verify error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewSecret()
+	if !strings.HasPrefix(a, "whsec_") || len(a) != len("whsec_")+64 || a == b {
+		t.Errorf("NewSecret = %q, %q", a, b)
+	}
+}
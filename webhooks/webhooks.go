@@ -0,0 +1,228 @@
+// Package webhooks meneruskan event dari event bus ke URL subscription milik tool luar.
+// Setiap event yang cocok disimpan sebagai WebhookDelivery, lalu worker mengirimnya
+// dengan signature HMAC dan retry exponential backoff. Pengiriman yang gagal terus
+// sampai MaxAttempts berstatus dead (dead-letter) dan bisa dikirim ulang manual.
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	db "cash-flow-go/database"
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+// Pengaturan retry dan worker
+const (
+	MaxAttempts  = 8
+	baseBackoff  = 30 * time.Second
+	maxBackoff   = 6 * time.Hour
+	pollInterval = 5 * time.Second
+	batchSize    = 20
+	sendTimeout  = 10 * time.Second
+	claimLease   = time.Minute // harus lebih lama dari sendTimeout
+)
+
+var client = &http.Client{Timeout: sendTimeout}
+
+// kick membangunkan worker tanpa menunggu tick berikutnya
+var kick = make(chan struct{}, 1)
+
+// ValidPattern mengecek pola filter event: tipe event yang dikenal, "<grup>.*" atau "*"
+func ValidPattern(p string) bool {
+	if p == "*" {
+		return true
+	}
+	prefix, wildcard := strings.CutSuffix(p, ".*")
+	for _, t := range events.Types {
+		if t == p || (wildcard && strings.HasPrefix(t, prefix+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff adalah jeda sebelum percobaan berikutnya setelah percobaan ke-attempt gagal:
+// 30 detik, 1 menit, 2 menit, ... maksimal 6 jam, ditambah jitter sampai 20%.
+func Backoff(attempt int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, maxBackoff)
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
+// Start menjalankan dispatcher (event bus -> delivery) dan worker pengiriman
+func Start() {
+	sub := events.Default.Subscribe(nil, 1024)
+	go func() {
+		for e := range sub.C {
+			if sub.Lagged() {
+				log.Println("webhook: event bus penuh, sebagian event tidak terkirim ke webhook")
+			}
+			if err := enqueue(e); err != nil {
+				log.Printf("webhook: gagal mengantrekan event %s: %v", e.Type, err)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-kick:
+			}
+			deliverDue()
+		}
+	}()
+}
+
+// Kick meminta worker segera memproses antrean (misal setelah redeliver manual)
+func Kick() {
+	select {
+	case kick <- struct{}{}:
+	default:
+	}
+}
+
+// receives mengecek apakah subscription menerima event. Event global (anomaly.detected)
+// dikirim ke subscription semua workspace, sama dengan SSE dan notifikasi.
+func receives(s models.WebhookSubscription, e events.Event) bool {
+	if e.Global() {
+		return s.Matches(e.Type, s.Workspace)
+	}
+	return s.Matches(e.Type, e.Workspace)
+}
+
+// enqueue membuat satu delivery untuk setiap subscription aktif yang cocok dengan event
+func enqueue(e events.Event) error {
+	subs, err := db.ActiveWebhooks()
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	var deliveries []models.WebhookDelivery
+	for _, s := range subs {
+		if !receives(s, e) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(e); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: s.ID,
+			EventType:      e.Type,
+			Payload:        string(payload),
+			Status:         models.DeliveryPending,
+			NextAttemptAt:  time.Now(),
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := db.DB.Create(&deliveries).Error; err != nil {
+		return err
+	}
+	Kick()
+	return nil
+}
+
+func deliverDue() {
+	for {
+		due, err := db.ClaimDueDeliveries(time.Now(), claimLease, batchSize)
+		if err != nil {
+			log.Printf("webhook: gagal mengambil antrean: %v", err)
+			return
+		}
+		for i := range due {
+			deliver(&due[i])
+		}
+		if len(due) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver mengirim satu delivery dan mencatat hasilnya
+func deliver(d *models.WebhookDelivery) {
+	sub, err := db.GetWebhook(d.SubscriptionID)
+	if err != nil {
+		log.Printf("webhook: subscription %d untuk delivery %d: %v", d.SubscriptionID, d.ID, err)
+		return
+	}
+
+	start := time.Now()
+	code, sendErr := send(sub, d)
+	attempt := models.WebhookAttempt{StatusCode: code, DurationMs: time.Since(start).Milliseconds()}
+
+	d.Attempts++
+	d.LastStatusCode = code
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		d.Status = models.DeliverySucceeded
+		d.DeliveredAt = &now
+		d.LastError = ""
+	case !sub.IsActive || d.Attempts >= MaxAttempts:
+		d.Status = models.DeliveryDead
+		d.LastError = sendErr.Error()
+		attempt.Error = sendErr.Error()
+	default:
+		d.NextAttemptAt = time.Now().Add(Backoff(d.Attempts))
+		d.LastError = sendErr.Error()
+		attempt.Error = sendErr.Error()
+	}
+
+	if err := db.RecordDeliveryAttempt(d, attempt); err != nil {
+		log.Printf("webhook: gagal menyimpan hasil delivery %d: %v", d.ID, err)
+	}
+}
+
+// send melakukan POST payload ke URL subscription. Respon 2xx dianggap sukses.
+func send(sub *models.WebhookSubscription, d *models.WebhookDelivery) (int, error) {
+	if !sub.IsActive {
+		return 0, errors.New("subscription nonaktif")
+	}
+
+	body := []byte(d.Payload)
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cash-flow-go-webhooks/1")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, fmt.Sprint(d.ID))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, time.Now(), body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := fmt.Sprintf("HTTP %d", resp.StatusCode)
+		if s := strings.TrimSpace(string(snippet)); s != "" {
+			msg += ": " + s
+		}
+		return resp.StatusCode, errors.New(msg)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"cash-flow-go/events"
+	"cash-flow-go/models"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{5, 8 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, maxBackoff},
+		{50, maxBackoff},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			got := Backoff(tt.attempt)
+			if got < tt.base || got > tt.base+tt.base/5 {
+				t.Fatalf("Backoff(%d) = %v, want %v + jitter sampai 20%%", tt.attempt, got, tt.base)
+			}
+		}
+	}
+}
+
+func TestValidPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*", true},
+		{"transaction.created", true},
+		{"transaction.*", true},
+		{"bill.*", true},
+		{"transaction.exploded", false},
+		{"unknown.*", false},
+		{"transaction", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidPattern(tt.pattern); got != tt.want {
+			t.Errorf("ValidPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestReceives(t *testing.T) {
+	sub := models.WebhookSubscription{Events: []string{"*"}, Workspace: "ws"}
+	tests := []struct {
+		name  string
+		event events.Event
+		want  bool
+	}{
+		{"same workspace", events.Event{Type: events.TransactionCreated, UserID: "budi", Workspace: "ws"}, true},
+		{"other workspace", events.Event{Type: events.TransactionCreated, UserID: "budi", Workspace: "other"}, false},
+		{"user without workspace", events.Event{Type: events.TransactionCreated, UserID: "budi"}, false},
+		{"global anomaly", events.Event{Type: events.AnomalyDetected}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := receives(sub, tt.event); got != tt.want {
+				t.Errorf("receives(%+v) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+
+	billsOnly := models.WebhookSubscription{Events: []string{"bill.*"}, Workspace: "ws"}
+	if receives(billsOnly, events.Event{Type: events.AnomalyDetected}) {
+		t.Error("global event delivered to a subscription that did not ask for it")
+	}
+}